@@ -0,0 +1,1014 @@
+package libpod
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+)
+
+// schemaVersion is the version of the on-disk BoltDB schema this build of
+// libpod knows how to read and write. It must be bumped any time the layout
+// of a bucket changes in a way that is not backwards compatible, and
+// Runtime.Migrate updated to handle the upgrade
+const schemaVersion = 1
+
+var (
+	idRegistryBucket    = []byte("id-registry")
+	nameRegistryBucket  = []byte("name-registry")
+	aliasesBucket       = []byte("aliases")
+	ctrBucket           = []byte("ctr")
+	ctrStateBucket      = []byte("ctr-state")
+	podBucket           = []byte("pod")
+	ctrDepsBucket       = []byte("ctr-deps")
+	volBucket           = []byte("volume")
+	volDepsBucket       = []byte("vol-deps")
+	runtimeConfigBucket = []byte("runtime-config")
+	schemaVersionKey    = []byte("schema-version")
+)
+
+// BoltState is a BoltDB-backed implementation of the State interface that
+// persists containers and pods to a single file on disk, allowing state to
+// be shared between processes and to survive a restart of the runtime
+type BoltState struct {
+	valid  bool
+	dbPath string
+	db     *bolt.DB
+}
+
+// NewBoltState creates a new BoltDB-backed state at the given path,
+// creating the database and its buckets if they do not already exist
+func NewBoltState(path string) (State, error) {
+	state := new(BoltState)
+	state.dbPath = path
+
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening database %s", path)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, bkt := range [][]byte{idRegistryBucket, nameRegistryBucket, aliasesBucket, ctrBucket, ctrStateBucket, podBucket, ctrDepsBucket, volBucket, volDepsBucket, runtimeConfigBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bkt); err != nil {
+				return errors.Wrapf(err, "error creating bucket %s", string(bkt))
+			}
+		}
+
+		runtimeBkt := tx.Bucket(runtimeConfigBucket)
+		if runtimeBkt.Get(schemaVersionKey) == nil {
+			if err := runtimeBkt.Put(schemaVersionKey, []byte(jsonInt(schemaVersion))); err != nil {
+				return errors.Wrapf(err, "error recording schema version")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	state.db = db
+	state.valid = true
+
+	return state, nil
+}
+
+// jsonInt renders an int as a decimal string suitable for storage as a
+// BoltDB value
+func jsonInt(i int) string {
+	b, _ := json.Marshal(i)
+	return string(b)
+}
+
+// getSchemaVersion reads the schema version a BoltDB state was created with
+// without otherwise opening it for use, so Runtime can decide whether to
+// refuse to start or to call Migrate
+func getSchemaVersion(path string) (int, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error opening database %s", path)
+	}
+	defer db.Close()
+
+	return readSchemaVersion(db)
+}
+
+// readSchemaVersion reads the schema version recorded in an already-open
+// database
+func readSchemaVersion(db *bolt.DB) (int, error) {
+	var version int
+	if err := db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(runtimeConfigBucket)
+		if bkt == nil {
+			// No runtime config bucket - this is a brand new,
+			// unversioned database
+			version = schemaVersion
+			return nil
+		}
+
+		verBytes := bkt.Get(schemaVersionKey)
+		if verBytes == nil {
+			version = schemaVersion
+			return nil
+		}
+
+		return json.Unmarshal(verBytes, &version)
+	}); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (s *BoltState) Close() error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	s.valid = false
+
+	return s.db.Close()
+}
+
+func (s *BoltState) AddContainer(ctr *Container) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		ctrBkt := tx.Bucket(ctrBucket)
+		idBkt := tx.Bucket(idRegistryBucket)
+		nameBkt := tx.Bucket(nameRegistryBucket)
+
+		id := []byte(ctr.ID())
+		name := []byte(ctr.Name())
+
+		if idBkt.Get(id) != nil {
+			return errors.Wrapf(ErrCtrExists, "container with ID %s already exists in state", ctr.ID())
+		}
+		if nameBkt.Get(name) != nil {
+			return errors.Wrapf(ErrCtrExists, "container with name %s already exists in state", ctr.Name())
+		}
+
+		if ctr.config.PodID != "" {
+			podBkt := tx.Bucket(podBucket)
+			if podBkt.Get([]byte(ctr.config.PodID)) == nil {
+				return errors.Wrapf(ErrNoSuchPod, "pod with ID %s does not exist in state", ctr.config.PodID)
+			}
+		}
+
+		depsBkt := tx.Bucket(ctrDepsBucket)
+		for _, dep := range ctr.config.Dependencies {
+			if ctrBkt.Get([]byte(dep)) == nil {
+				return errors.Wrapf(ErrNoSuchCtr, "dependency container %s does not exist in state", dep)
+			}
+			if err := addContainerDependent(depsBkt, dep, ctr.ID()); err != nil {
+				return errors.Wrapf(err, "error recording dependency on container %s", dep)
+			}
+		}
+
+		volBkt := tx.Bucket(volBucket)
+		volDepsBkt := tx.Bucket(volDepsBucket)
+		for _, volName := range ctr.config.Volumes {
+			if volBkt.Get([]byte(volName)) == nil {
+				return errors.Wrapf(ErrNoSuchVolume, "volume %s does not exist in state", volName)
+			}
+			if err := addContainerDependent(volDepsBkt, volName, ctr.ID()); err != nil {
+				return errors.Wrapf(err, "error recording use of volume %s", volName)
+			}
+		}
+
+		configJSON, err := json.Marshal(ctr.config)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling container %s config", ctr.ID())
+		}
+
+		stateJSON, err := json.Marshal(ctr.state)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling container %s state", ctr.ID())
+		}
+
+		if err := idBkt.Put(id, name); err != nil {
+			return err
+		}
+		if err := nameBkt.Put(name, id); err != nil {
+			return err
+		}
+		if err := ctrBkt.Put(id, configJSON); err != nil {
+			return err
+		}
+		stateBkt := tx.Bucket(ctrStateBucket)
+		return stateBkt.Put(id, stateJSON)
+	})
+}
+
+func (s *BoltState) RemoveContainer(ctr *Container) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return removeContainerTx(tx, ctr)
+	})
+}
+
+// removeContainerTx performs the actual work of RemoveContainer within an
+// already-open transaction, so multiple containers can be removed as part
+// of one larger atomic operation (see RemovePodContainers)
+func removeContainerTx(tx *bolt.Tx, ctr *Container) error {
+	ctrBkt := tx.Bucket(ctrBucket)
+	stateBkt := tx.Bucket(ctrStateBucket)
+	idBkt := tx.Bucket(idRegistryBucket)
+	nameBkt := tx.Bucket(nameRegistryBucket)
+	depsBkt := tx.Bucket(ctrDepsBucket)
+
+	id := []byte(ctr.ID())
+
+	if ctrBkt.Get(id) == nil {
+		return errors.Wrapf(ErrNoSuchCtr, "container with ID %s does not exist in state", ctr.ID())
+	}
+
+	for _, dep := range ctr.config.Dependencies {
+		if err := removeContainerDependent(depsBkt, dep, ctr.ID()); err != nil {
+			return errors.Wrapf(err, "error clearing dependency on container %s", dep)
+		}
+	}
+
+	volDepsBkt := tx.Bucket(volDepsBucket)
+	for _, volName := range ctr.config.Volumes {
+		if err := removeContainerDependent(volDepsBkt, volName, ctr.ID()); err != nil {
+			return errors.Wrapf(err, "error clearing use of volume %s", volName)
+		}
+	}
+
+	if err := ctrBkt.Delete(id); err != nil {
+		return err
+	}
+	if err := stateBkt.Delete(id); err != nil {
+		return err
+	}
+	if err := idBkt.Delete(id); err != nil {
+		return err
+	}
+	if err := nameBkt.Delete([]byte(ctr.Name())); err != nil {
+		return err
+	}
+	return depsBkt.Delete(id)
+}
+
+// addContainerDependent records in the ctr-deps bucket that dependentID
+// depends on depID
+func addContainerDependent(depsBkt *bolt.Bucket, depID, dependentID string) error {
+	var dependents []string
+
+	depBytes := depsBkt.Get([]byte(depID))
+	if depBytes != nil {
+		if err := json.Unmarshal(depBytes, &dependents); err != nil {
+			return errors.Wrapf(err, "error unmarshalling dependents of container %s", depID)
+		}
+	}
+
+	for _, existing := range dependents {
+		if existing == dependentID {
+			return nil
+		}
+	}
+	dependents = append(dependents, dependentID)
+
+	newBytes, err := json.Marshal(dependents)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling dependents of container %s", depID)
+	}
+
+	return depsBkt.Put([]byte(depID), newBytes)
+}
+
+// removeContainerDependent undoes addContainerDependent
+func removeContainerDependent(depsBkt *bolt.Bucket, depID, dependentID string) error {
+	depBytes := depsBkt.Get([]byte(depID))
+	if depBytes == nil {
+		return nil
+	}
+
+	var dependents []string
+	if err := json.Unmarshal(depBytes, &dependents); err != nil {
+		return errors.Wrapf(err, "error unmarshalling dependents of container %s", depID)
+	}
+
+	newDependents := make([]string, 0, len(dependents))
+	for _, existing := range dependents {
+		if existing != dependentID {
+			newDependents = append(newDependents, existing)
+		}
+	}
+
+	if len(newDependents) == 0 {
+		return depsBkt.Delete([]byte(depID))
+	}
+
+	newBytes, err := json.Marshal(newDependents)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling dependents of container %s", depID)
+	}
+
+	return depsBkt.Put([]byte(depID), newBytes)
+}
+
+// ContainerInUse returns the IDs of containers that depend on the given
+// container
+func (s *BoltState) ContainerInUse(ctr *Container) ([]string, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var dependents []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		depsBkt := tx.Bucket(ctrDepsBucket)
+
+		depBytes := depsBkt.Get([]byte(ctr.ID()))
+		if depBytes == nil {
+			return nil
+		}
+
+		return json.Unmarshal(depBytes, &dependents)
+	})
+
+	return dependents, err
+}
+
+// UpdateContainer refreshes both the config and the state of ctr from the
+// database
+func (s *BoltState) UpdateContainer(ctr *Container) error {
+	return s.withContainer(ctr.ID(), func(config *ContainerConfig, state *containerState) {
+		*ctr.config = *config
+		*ctr.state = *state
+	})
+}
+
+func (s *BoltState) SaveContainer(ctr *Container) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		ctrBkt := tx.Bucket(ctrBucket)
+		stateBkt := tx.Bucket(ctrStateBucket)
+
+		id := []byte(ctr.ID())
+		if ctrBkt.Get(id) == nil {
+			return errors.Wrapf(ErrNoSuchCtr, "container with ID %s does not exist in state", ctr.ID())
+		}
+
+		configJSON, err := json.Marshal(ctr.config)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling container %s config", ctr.ID())
+		}
+
+		stateJSON, err := json.Marshal(ctr.state)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling container %s state", ctr.ID())
+		}
+
+		if err := ctrBkt.Put(id, configJSON); err != nil {
+			return err
+		}
+		return stateBkt.Put(id, stateJSON)
+	})
+}
+
+// getContainerStateDB reads a container's state from the ctr-state bucket,
+// returning a zero-valued containerState if id has no entry in it yet
+func getContainerStateDB(stateBkt *bolt.Bucket, id []byte) (*containerState, error) {
+	state := new(containerState)
+
+	stateBytes := stateBkt.Get(id)
+	if stateBytes == nil {
+		return state, nil
+	}
+
+	if err := json.Unmarshal(stateBytes, state); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling container %s state", string(id))
+	}
+
+	return state, nil
+}
+
+// withContainer reads a container's config and state from the database and
+// passes them to the given function, used to implement the read paths of the
+// State interface without repeating the lookup boilerplate
+func (s *BoltState) withContainer(id string, f func(config *ContainerConfig, state *containerState)) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.View(func(tx *bolt.Tx) error {
+		ctrBkt := tx.Bucket(ctrBucket)
+		stateBkt := tx.Bucket(ctrStateBucket)
+
+		configBytes := ctrBkt.Get([]byte(id))
+		if configBytes == nil {
+			return errors.Wrapf(ErrNoSuchCtr, "container with ID %s does not exist in state", id)
+		}
+
+		config := new(ContainerConfig)
+		if err := json.Unmarshal(configBytes, config); err != nil {
+			return errors.Wrapf(err, "error unmarshalling container %s config", id)
+		}
+
+		state, err := getContainerStateDB(stateBkt, []byte(id))
+		if err != nil {
+			return err
+		}
+
+		f(config, state)
+
+		return nil
+	})
+}
+
+func (s *BoltState) GetContainer(id string) (*Container, error) {
+	ctr := new(Container)
+
+	if err := s.withContainer(id, func(config *ContainerConfig, state *containerState) {
+		ctr.config = config
+		ctr.state = state
+	}); err != nil {
+		return nil, err
+	}
+
+	ctr.valid = true
+
+	return ctr, nil
+}
+
+func (s *BoltState) HasContainer(id string) (bool, error) {
+	if !s.valid {
+		return false, ErrDBClosed
+	}
+
+	exists := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ctrBkt := tx.Bucket(ctrBucket)
+		exists = ctrBkt.Get([]byte(id)) != nil
+		return nil
+	})
+
+	return exists, err
+}
+
+func (s *BoltState) LookupContainer(idOrName string) (*Container, error) {
+	if idOrName == "" {
+		return nil, ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var fullID string
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		nameBkt := tx.Bucket(nameRegistryBucket)
+		idBkt := tx.Bucket(idRegistryBucket)
+
+		if id := nameBkt.Get([]byte(idOrName)); id != nil {
+			fullID = string(id)
+			return nil
+		}
+
+		if idBkt.Get([]byte(idOrName)) != nil {
+			fullID = idOrName
+			return nil
+		}
+
+		// Fall back to a partial ID match
+		match := ""
+		if err := idBkt.ForEach(func(k, v []byte) error {
+			if strings.HasPrefix(string(k), idOrName) {
+				if match != "" {
+					return errors.Wrapf(ErrCtrExists, "more than one result for ID or name %s", idOrName)
+				}
+				match = string(k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if match == "" {
+			return errors.Wrapf(ErrNoSuchCtr, "no container with name or ID %s found", idOrName)
+		}
+		fullID = match
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetContainer(fullID)
+}
+
+func (s *BoltState) GetAllContainers() ([]*Container, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var ctrs []*Container
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ctrBkt := tx.Bucket(ctrBucket)
+		stateBkt := tx.Bucket(ctrStateBucket)
+
+		return ctrBkt.ForEach(func(id, configBytes []byte) error {
+			config := new(ContainerConfig)
+			if err := json.Unmarshal(configBytes, config); err != nil {
+				return errors.Wrapf(err, "error unmarshalling container %s config", string(id))
+			}
+
+			state, err := getContainerStateDB(stateBkt, id)
+			if err != nil {
+				return err
+			}
+
+			ctr := new(Container)
+			ctr.config = config
+			ctr.state = state
+			ctr.valid = true
+
+			ctrs = append(ctrs, ctr)
+
+			return nil
+		})
+	})
+
+	return ctrs, err
+}
+
+func (s *BoltState) AddPod(pod *Pod) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		podBkt := tx.Bucket(podBucket)
+		idBkt := tx.Bucket(idRegistryBucket)
+		nameBkt := tx.Bucket(nameRegistryBucket)
+
+		id := []byte(pod.ID())
+		name := []byte(pod.Name())
+
+		if idBkt.Get(id) != nil {
+			return errors.Wrapf(ErrPodExists, "pod with ID %s already exists in state", pod.ID())
+		}
+		if nameBkt.Get(name) != nil {
+			return errors.Wrapf(ErrPodExists, "pod with name %s already exists in state", pod.Name())
+		}
+
+		configJSON, err := json.Marshal(pod.config)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling pod %s config", pod.ID())
+		}
+
+		if err := idBkt.Put(id, name); err != nil {
+			return err
+		}
+		if err := nameBkt.Put(name, id); err != nil {
+			return err
+		}
+		return podBkt.Put(id, configJSON)
+	})
+}
+
+func (s *BoltState) RemovePod(pod *Pod) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return removePodTx(tx, pod)
+	})
+}
+
+// removePodTx performs the actual work of RemovePod within an already-open
+// transaction, so it can be combined with container removal into one larger
+// atomic operation (see RemovePodContainers)
+func removePodTx(tx *bolt.Tx, pod *Pod) error {
+	podBkt := tx.Bucket(podBucket)
+	idBkt := tx.Bucket(idRegistryBucket)
+	nameBkt := tx.Bucket(nameRegistryBucket)
+
+	id := []byte(pod.ID())
+
+	if podBkt.Get(id) == nil {
+		return errors.Wrapf(ErrNoSuchPod, "pod with ID %s does not exist in state", pod.ID())
+	}
+
+	if err := tx.Bucket(ctrBucket).ForEach(func(ctrID, configBytes []byte) error {
+		config := new(ContainerConfig)
+		if err := json.Unmarshal(configBytes, config); err != nil {
+			return errors.Wrapf(err, "error unmarshalling container %s config", string(ctrID))
+		}
+		if config.PodID == pod.ID() {
+			return errors.Wrapf(ErrCtrExists, "pod %s still has containers present, cannot remove", pod.ID())
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := podBkt.Delete(id); err != nil {
+		return err
+	}
+	if err := idBkt.Delete(id); err != nil {
+		return err
+	}
+	return nameBkt.Delete([]byte(pod.Name()))
+}
+
+// RemovePodContainers removes a pod's member containers and the pod itself
+// from the database within a single transaction, so a mid-operation failure
+// here leaves every container and the pod exactly as they were on disk,
+// instead of some containers gone and others - or the pod - still present
+func (s *BoltState) RemovePodContainers(pod *Pod, ctrs []*Container) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, ctr := range ctrs {
+			if err := removeContainerTx(tx, ctr); err != nil {
+				return errors.Wrapf(err, "error removing container %s from pod %s", ctr.ID(), pod.ID())
+			}
+		}
+
+		return removePodTx(tx, pod)
+	})
+}
+
+func (s *BoltState) SavePod(pod *Pod) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		podBkt := tx.Bucket(podBucket)
+
+		id := []byte(pod.ID())
+		if podBkt.Get(id) == nil {
+			return errors.Wrapf(ErrNoSuchPod, "pod with ID %s does not exist in state", pod.ID())
+		}
+
+		configJSON, err := json.Marshal(pod.config)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling pod %s config", pod.ID())
+		}
+
+		return podBkt.Put(id, configJSON)
+	})
+}
+
+func (s *BoltState) GetPod(id string) (*Pod, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	pod := new(Pod)
+	pod.containers = make(map[string]*Container)
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		podBkt := tx.Bucket(podBucket)
+
+		configBytes := podBkt.Get([]byte(id))
+		if configBytes == nil {
+			return errors.Wrapf(ErrNoSuchPod, "pod with ID %s does not exist in state", id)
+		}
+
+		config := new(PodConfig)
+		if err := json.Unmarshal(configBytes, config); err != nil {
+			return errors.Wrapf(err, "error unmarshalling pod %s config", id)
+		}
+		pod.config = config
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	pod.valid = true
+
+	return pod, nil
+}
+
+func (s *BoltState) HasPod(id string) (bool, error) {
+	if !s.valid {
+		return false, ErrDBClosed
+	}
+
+	exists := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		podBkt := tx.Bucket(podBucket)
+		exists = podBkt.Get([]byte(id)) != nil
+		return nil
+	})
+
+	return exists, err
+}
+
+func (s *BoltState) LookupPod(idOrName string) (*Pod, error) {
+	if idOrName == "" {
+		return nil, ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var fullID string
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		nameBkt := tx.Bucket(nameRegistryBucket)
+		idBkt := tx.Bucket(idRegistryBucket)
+
+		if id := nameBkt.Get([]byte(idOrName)); id != nil {
+			fullID = string(id)
+			return nil
+		}
+
+		if idBkt.Get([]byte(idOrName)) != nil {
+			fullID = idOrName
+			return nil
+		}
+
+		match := ""
+		if err := idBkt.ForEach(func(k, v []byte) error {
+			if strings.HasPrefix(string(k), idOrName) {
+				if match != "" {
+					return errors.Wrapf(ErrPodExists, "more than one result for ID or name %s", idOrName)
+				}
+				match = string(k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if match == "" {
+			return errors.Wrapf(ErrNoSuchPod, "no pod with name or ID %s found", idOrName)
+		}
+		fullID = match
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetPod(fullID)
+}
+
+func (s *BoltState) GetAllPods() ([]*Pod, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var pods []*Pod
+	err := s.db.View(func(tx *bolt.Tx) error {
+		podBkt := tx.Bucket(podBucket)
+
+		return podBkt.ForEach(func(id, configBytes []byte) error {
+			config := new(PodConfig)
+			if err := json.Unmarshal(configBytes, config); err != nil {
+				return errors.Wrapf(err, "error unmarshalling pod %s config", string(id))
+			}
+
+			pod := new(Pod)
+			pod.config = config
+			pod.containers = make(map[string]*Container)
+			pod.valid = true
+
+			pods = append(pods, pod)
+
+			return nil
+		})
+	})
+
+	return pods, err
+}
+
+func (s *BoltState) PodContainers(pod *Pod) ([]*Container, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var ctrs []*Container
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ctrBkt := tx.Bucket(ctrBucket)
+		stateBkt := tx.Bucket(ctrStateBucket)
+
+		return ctrBkt.ForEach(func(id, configBytes []byte) error {
+			config := new(ContainerConfig)
+			if err := json.Unmarshal(configBytes, config); err != nil {
+				return errors.Wrapf(err, "error unmarshalling container %s config", string(id))
+			}
+
+			if config.PodID != pod.ID() {
+				return nil
+			}
+
+			state, err := getContainerStateDB(stateBkt, id)
+			if err != nil {
+				return err
+			}
+
+			ctr := new(Container)
+			ctr.config = config
+			ctr.state = state
+			ctr.valid = true
+
+			ctrs = append(ctrs, ctr)
+
+			return nil
+		})
+	})
+
+	return ctrs, err
+}
+
+func (s *BoltState) AddVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		volBkt := tx.Bucket(volBucket)
+
+		name := []byte(volume.Name())
+		if volBkt.Get(name) != nil {
+			return errors.Wrapf(ErrVolumeExists, "volume with name %s already exists in state", volume.Name())
+		}
+
+		configJSON, err := json.Marshal(volume.config)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling volume %s config", volume.Name())
+		}
+
+		return volBkt.Put(name, configJSON)
+	})
+}
+
+func (s *BoltState) RemoveVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		volBkt := tx.Bucket(volBucket)
+		volDepsBkt := tx.Bucket(volDepsBucket)
+
+		name := []byte(volume.Name())
+		if volBkt.Get(name) == nil {
+			return errors.Wrapf(ErrNoSuchVolume, "volume with name %s does not exist in state", volume.Name())
+		}
+
+		if err := volBkt.Delete(name); err != nil {
+			return err
+		}
+		return volDepsBkt.Delete(name)
+	})
+}
+
+func (s *BoltState) GetVolume(name string) (*Volume, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	vol := new(Volume)
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		volBkt := tx.Bucket(volBucket)
+
+		configBytes := volBkt.Get([]byte(name))
+		if configBytes == nil {
+			return errors.Wrapf(ErrNoSuchVolume, "volume with name %s does not exist in state", name)
+		}
+
+		config := new(VolumeConfig)
+		if err := json.Unmarshal(configBytes, config); err != nil {
+			return errors.Wrapf(err, "error unmarshalling volume %s config", name)
+		}
+		vol.config = config
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	vol.valid = true
+
+	return vol, nil
+}
+
+func (s *BoltState) LookupVolume(name string) (*Volume, error) {
+	if name == "" {
+		return nil, ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var fullName string
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		volBkt := tx.Bucket(volBucket)
+
+		if volBkt.Get([]byte(name)) != nil {
+			fullName = name
+			return nil
+		}
+
+		match := ""
+		if err := volBkt.ForEach(func(k, v []byte) error {
+			if strings.HasPrefix(string(k), name) {
+				if match != "" {
+					return errors.Wrapf(ErrVolumeExists, "more than one result for name %s", name)
+				}
+				match = string(k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if match == "" {
+			return errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found", name)
+		}
+		fullName = match
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetVolume(fullName)
+}
+
+func (s *BoltState) HasVolume(name string) (bool, error) {
+	if !s.valid {
+		return false, ErrDBClosed
+	}
+
+	exists := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		volBkt := tx.Bucket(volBucket)
+		exists = volBkt.Get([]byte(name)) != nil
+		return nil
+	})
+
+	return exists, err
+}
+
+func (s *BoltState) GetAllVolumes() ([]*Volume, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var vols []*Volume
+	err := s.db.View(func(tx *bolt.Tx) error {
+		volBkt := tx.Bucket(volBucket)
+
+		return volBkt.ForEach(func(name, configBytes []byte) error {
+			config := new(VolumeConfig)
+			if err := json.Unmarshal(configBytes, config); err != nil {
+				return errors.Wrapf(err, "error unmarshalling volume %s config", string(name))
+			}
+
+			vol := new(Volume)
+			vol.config = config
+			vol.valid = true
+
+			vols = append(vols, vol)
+
+			return nil
+		})
+	})
+
+	return vols, err
+}
+
+// VolumeInUse returns the IDs of containers that reference the given volume
+func (s *BoltState) VolumeInUse(volume *Volume) ([]string, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	var users []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		volDepsBkt := tx.Bucket(volDepsBucket)
+
+		depBytes := volDepsBkt.Get([]byte(volume.Name()))
+		if depBytes == nil {
+			return nil
+		}
+
+		return json.Unmarshal(depBytes, &users)
+	})
+
+	return users, err
+}
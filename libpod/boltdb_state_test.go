@@ -0,0 +1,118 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+)
+
+func TestJSONInt(t *testing.T) {
+	tests := []struct {
+		input int
+		want  string
+	}{
+		{input: 0, want: "0"},
+		{input: 1, want: "1"},
+		{input: 42, want: "42"},
+	}
+
+	for _, tt := range tests {
+		if got := jsonInt(tt.input); got != tt.want {
+			t.Errorf("jsonInt(%d) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGetSchemaVersionUnversionedDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb-state-test-")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, "state.db")
+
+	// Create a brand new, empty database with none of NewBoltState's
+	// buckets - as if it predated the schema-version bucket entirely
+	db, err := bolt.Open(dbPath, 0600, nil)
+	if err != nil {
+		t.Fatalf("error creating database: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("error closing database: %v", err)
+	}
+
+	version, err := getSchemaVersion(dbPath)
+	if err != nil {
+		t.Fatalf("getSchemaVersion returned an error: %v", err)
+	}
+	if version != schemaVersion {
+		t.Errorf("getSchemaVersion of an unversioned database = %d, want %d", version, schemaVersion)
+	}
+}
+
+func TestNewBoltStateRecordsSchemaVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb-state-test-")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, "state.db")
+
+	state, err := NewBoltState(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltState returned an error: %v", err)
+	}
+	// getSchemaVersion opens the database itself, which BoltDB does not
+	// allow while the state still holds it open
+	if err := state.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	version, err := getSchemaVersion(dbPath)
+	if err != nil {
+		t.Fatalf("getSchemaVersion returned an error: %v", err)
+	}
+	if version != schemaVersion {
+		t.Errorf("schema version recorded by NewBoltState = %d, want %d", version, schemaVersion)
+	}
+}
+
+func TestBoltStateContainerStateSurvivesReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb-state-test-")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	state, err := NewBoltState(filepath.Join(dir, "state.db"))
+	if err != nil {
+		t.Fatalf("NewBoltState returned an error: %v", err)
+	}
+	defer state.Close()
+
+	ctr := &Container{
+		config: &ContainerConfig{ID: "abc123", Name: "testctr"},
+		state:  &containerState{State: ContainerStateRunning, PID: 4242},
+	}
+
+	if err := state.AddContainer(ctr); err != nil {
+		t.Fatalf("AddContainer returned an error: %v", err)
+	}
+
+	fetched, err := state.GetContainer(ctr.ID())
+	if err != nil {
+		t.Fatalf("GetContainer returned an error: %v", err)
+	}
+
+	if fetched.state.State != ContainerStateRunning {
+		t.Errorf("GetContainer state = %v, want %v", fetched.state.State, ContainerStateRunning)
+	}
+	if fetched.state.PID != 4242 {
+		t.Errorf("GetContainer PID = %d, want 4242", fetched.state.PID)
+	}
+}
@@ -0,0 +1,179 @@
+package libpod
+
+import (
+	"time"
+
+	"github.com/docker/docker/pkg/namesgenerator"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/kubernetes-incubator/cri-o/libpod/lock"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/ulule/deepcopier"
+)
+
+// ContainerState represents the current state of a container
+type ContainerState int
+
+const (
+	// ContainerStateUnknown indicates that the container is in an error
+	// state where information about it cannot be retrieved
+	ContainerStateUnknown ContainerState = iota
+	// ContainerStateConfigured indicates that the container has had its
+	// storage configured but not yet created in the OCI runtime
+	ContainerStateConfigured
+	// ContainerStateCreated indicates the container has been created in
+	// the OCI runtime but not started
+	ContainerStateCreated
+	// ContainerStateRunning indicates the container is currently running
+	ContainerStateRunning
+	// ContainerStateStopped indicates that the container was running but
+	// has now stopped
+	ContainerStateStopped
+	// ContainerStatePaused indicates that the container has been paused
+	ContainerStatePaused
+)
+
+// Container is a single OCI container
+type Container struct {
+	config *ContainerConfig
+	state  *containerState
+
+	pod     *Pod
+	runtime *Runtime
+	lock    lock.Locker
+	valid   bool
+}
+
+// ContainerConfig contains all information that was used to create the
+// container. This information is entirely static and does not change once
+// the container is created
+type ContainerConfig struct {
+	ID   string
+	Name string
+
+	Spec *spec.Spec
+
+	PodID string
+
+	// Dependencies is the set of IDs of other containers this container
+	// depends on - for example, containers whose IPC, network, or PID
+	// namespace it joins. A container cannot be removed while it still
+	// has dependents unless force is specified
+	Dependencies []string
+
+	// StaticDir is the directory on disk holding this container's
+	// per-container state: the conmon PID and exit files, and (for
+	// containers using the default storage driver) its mountpoint
+	StaticDir string
+
+	// Volumes is the set of names of named volumes mounted into this
+	// container. A volume cannot be removed while it remains in use by a
+	// container unless force is specified
+	Volumes []string
+
+	// LockID is the ID of the lock used to protect this container from
+	// concurrent access across processes
+	LockID uint32
+
+	CreatedTime time.Time
+}
+
+// containerState holds the mutable, in-flux state of a container - the
+// things that change as the container runs and is manipulated by the user
+type containerState struct {
+	State    ContainerState
+	PID      int
+	ExitCode int32
+	Finished time.Time
+}
+
+// newContainer creates a new container from a given OCI config
+func newContainer(ctrSpec *spec.Spec) (*Container, error) {
+	if ctrSpec == nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "must provide a valid runtime spec to create container")
+	}
+
+	ctr := new(Container)
+	ctr.config = new(ContainerConfig)
+	ctr.state = new(containerState)
+
+	ctr.config.ID = stringid.GenerateNonCryptoID()
+	ctr.config.Name = namesgenerator.GetRandomName(0)
+	ctr.config.Spec = new(spec.Spec)
+	deepcopier.Copy(ctrSpec).To(ctr.config.Spec)
+	ctr.config.CreatedTime = time.Now()
+
+	ctr.state.State = ContainerStateConfigured
+
+	return ctr, nil
+}
+
+// ID returns the container's ID
+func (c *Container) ID() string {
+	return c.config.ID
+}
+
+// Name returns the container's name
+func (c *Container) Name() string {
+	return c.config.Name
+}
+
+// Pod returns the pod this container belongs to, if any
+func (c *Container) Pod() *Pod {
+	return c.pod
+}
+
+// State returns the current state of the container
+func (c *Container) State() ContainerState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.state.State
+}
+
+// Spec returns a copy of the OCI runtime spec used to create the container
+func (c *Container) Spec() *spec.Spec {
+	newSpec := new(spec.Spec)
+	deepcopier.Copy(c.config.Spec).To(newSpec)
+
+	return newSpec
+}
+
+// Labels returns the container's labels, sourced from the OCI spec
+// annotations set on it at creation - the same source used to match the
+// "label" container filter
+func (c *Container) Labels() map[string]string {
+	spec := c.Spec()
+	if spec == nil {
+		return nil
+	}
+
+	labels := make(map[string]string, len(spec.Annotations))
+	for k, v := range spec.Annotations {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// Volumes returns the names of the named volumes used by the container
+func (c *Container) Volumes() []string {
+	volumes := make([]string, len(c.config.Volumes))
+	copy(volumes, c.config.Volumes)
+
+	return volumes
+}
+
+// CreatedTime returns the time the container was created
+func (c *Container) CreatedTime() time.Time {
+	return c.config.CreatedTime
+}
+
+// ExitCode returns the exit code of the container's last run, valid once the
+// container has stopped
+func (c *Container) ExitCode() int32 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.state.ExitCode
+}
@@ -0,0 +1,95 @@
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNoSuchCtr indicates the requested container does not exist
+	ErrNoSuchCtr = errors.New("no such container")
+	// ErrNoSuchPod indicates the requested pod does not exist
+	ErrNoSuchPod = errors.New("no such pod")
+	// ErrCtrExists indicates a container with the same ID or name already
+	// exists
+	ErrCtrExists = errors.New("container already exists")
+	// ErrPodExists indicates a pod with the same ID or name already
+	// exists
+	ErrPodExists = errors.New("pod already exists")
+	// ErrCtrRemoved indicates that the container has already been
+	// removed and no further operations can be performed on it
+	ErrCtrRemoved = errors.New("container has already been removed")
+	// ErrPodRemoved indicates that the pod has already been removed and
+	// no further operations can be performed on it
+	ErrPodRemoved = errors.New("pod has already been removed")
+	// ErrRuntimeStopped indicates that the runtime has already been shut
+	// down and no further operations can be performed on it
+	ErrRuntimeStopped = errors.New("runtime has already been stopped")
+	// ErrRuntimeFinalized indicates that the runtime has already been
+	// completely initialized, and no further RuntimeOptions can be used
+	ErrRuntimeFinalized = errors.New("runtime has already been finalized")
+	// ErrCtrFinalized indicates that the container has already been
+	// completely initialized, and no further CtrCreateOptions can be
+	// used
+	ErrCtrFinalized = errors.New("container has already been finalized")
+	// ErrPodFinalized indicates that the pod has already been completely
+	// initialized, and no further PodCreateOptions can be used
+	ErrPodFinalized = errors.New("pod has already been finalized")
+	// ErrNoSuchVolume indicates the requested volume does not exist
+	ErrNoSuchVolume = errors.New("no such volume")
+	// ErrVolumeExists indicates a volume with the same name already
+	// exists
+	ErrVolumeExists = errors.New("volume already exists")
+	// ErrVolumeRemoved indicates that the volume has already been
+	// removed and no further operations can be performed on it
+	ErrVolumeRemoved = errors.New("volume has already been removed")
+	// ErrVolumeFinalized indicates that the volume has already been
+	// completely initialized, and no further VolumeCreateOptions can be
+	// used
+	ErrVolumeFinalized = errors.New("volume has already been finalized")
+	// ErrCtrStopped indicates that the container is not running and the
+	// requested operation can only be performed on running containers
+	ErrCtrStopped = errors.New("container is stopped")
+	// ErrCtrStateInvalid indicates that the container is in an
+	// unexpected state given the operation being performed on it
+	ErrCtrStateInvalid = errors.New("container state improper")
+	// ErrNotImplemented indicates that the requested functionality is
+	// not yet present
+	ErrNotImplemented = errors.New("not yet implemented")
+	// ErrInternal indicates an internal library error
+	ErrInternal = errors.New("internal libpod error")
+	// ErrInvalidArg indicates that an invalid argument was passed
+	ErrInvalidArg = errors.New("invalid argument")
+	// ErrEmptyID indicates that an empty ID was passed
+	ErrEmptyID = errors.New("name or ID cannot be empty")
+	// ErrDBClosed indicates that the database backing the runtime's
+	// state has already been closed
+	ErrDBClosed = errors.New("database is already closed")
+	// ErrDBBadConfig indicates that the database configuration does not
+	// match the configuration of the runtime opening it
+	ErrDBBadConfig = errors.New("database configuration mismatch")
+	// ErrOSNotSupported indicates that the requested functionality is
+	// not available on the current OS
+	ErrOSNotSupported = errors.New("no support for this OS yet")
+	// ErrNoAliases indicates that aliases are not supported for the
+	// current network configuration
+	ErrNoAliases = errors.New("aliases are not supported with the network configuration")
+)
+
+// InUseError indicates that a container, pod, or volume could not be removed
+// because one or more containers still depend on it - for example, by
+// sharing one of its namespaces, belonging to the pod, or using the volume.
+// Callers can type-assert on this to present the list of blocking
+// dependents to the user, rather than a removal relying on force
+type InUseError struct {
+	// ID is the container or pod ID, or the volume name, that could not be
+	// removed
+	ID string
+	// Dependents is the list of container IDs that depend on ID
+	Dependents []string
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("%s is still in use by container(s) %s and cannot be removed without force", e.ID, strings.Join(e.Dependents, ", "))
+}
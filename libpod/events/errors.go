@@ -0,0 +1,12 @@
+package events
+
+import "errors"
+
+var (
+	// ErrNoJournaldLogging indicates that journald event logging was
+	// requested but the local journald socket could not be reached
+	ErrNoJournaldLogging = errors.New("journald support not available")
+	// ErrOSNotSupported indicates that the requested functionality is
+	// not available on the current OS
+	ErrOSNotSupported = errors.New("no support for this OS yet")
+)
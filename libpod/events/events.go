@@ -0,0 +1,115 @@
+// Package events defines the event types libpod emits over the course of a
+// container or pod's lifecycle, and the Eventer interface used to record and
+// retrieve them
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Type indicates the kind of entity that generated an event
+type Type string
+
+const (
+	// TypeContainer indicates the event was generated by a container
+	TypeContainer Type = "container"
+	// TypePod indicates the event was generated by a pod
+	TypePod Type = "pod"
+)
+
+// Status indicates what happened to the entity that generated an event
+type Status string
+
+const (
+	// StatusCreate is recorded when a container or pod is created
+	StatusCreate Status = "create"
+	// StatusStop is recorded when a running container is stopped
+	StatusStop Status = "stop"
+	// StatusRemove is recorded when a container or pod is removed
+	StatusRemove Status = "remove"
+)
+
+// Event represents a single occurrence recorded against a container or pod
+type Event struct {
+	// ID is the ID of the container or pod the event pertains to
+	ID string
+	// Name is the name of the container or pod the event pertains to
+	Name string
+	// Type is the kind of entity the event pertains to
+	Type Type
+	// Status is what happened to the entity
+	Status Status
+	// Time is when the event occurred
+	Time time.Time
+	// Labels carries a copy of the entity's labels at the time of the
+	// event, used for filtering
+	Labels map[string]string
+}
+
+// EventFilter is a predicate used to select a subset of events. Runtime.Events
+// ANDs every filter given to it together, matching the semantics of
+// ContainerFilter and PodFilter
+type EventFilter func(*Event) bool
+
+// SinceFilter returns an EventFilter matching events at or after since
+func SinceFilter(since time.Time) EventFilter {
+	return func(e *Event) bool {
+		return !e.Time.Before(since)
+	}
+}
+
+// UntilFilter returns an EventFilter matching events at or before until
+func UntilFilter(until time.Time) EventFilter {
+	return func(e *Event) bool {
+		return !e.Time.After(until)
+	}
+}
+
+// TypeFilter returns an EventFilter matching events of the given type
+func TypeFilter(t Type) EventFilter {
+	return func(e *Event) bool {
+		return e.Type == t
+	}
+}
+
+// LabelFilter returns an EventFilter matching events whose Labels contain
+// key with the given value. Only container events carry labels - pods have
+// no label source of their own, so a LabelFilter never matches a pod event
+func LabelFilter(key, value string) EventFilter {
+	return func(e *Event) bool {
+		v, ok := e.Labels[key]
+		return ok && v == value
+	}
+}
+
+// ReadOptions controls which events Read returns
+type ReadOptions struct {
+	// Filters restricts output to events matching every given filter
+	Filters []EventFilter
+	// Stream, if true, does not close the returned channel once existing
+	// events have been delivered, but continues delivering new events as
+	// they are written until ctx is canceled
+	Stream bool
+}
+
+// Eventer writes events to, and reads events from, a backing store
+type Eventer interface {
+	// Write records a single event
+	Write(event Event) error
+	// Read returns a channel of events matching the given options. The
+	// channel is closed once there are no more events to deliver to a
+	// non-streaming read, or when ctx is canceled for a streaming one
+	Read(ctx context.Context, options ReadOptions) (<-chan Event, error)
+}
+
+// matches reports whether event satisfies every filter in filters
+func matches(event *Event, filters []EventFilter) bool {
+	for _, filter := range filters {
+		if !filter(event) {
+			return false
+		}
+	}
+
+	return true
+}
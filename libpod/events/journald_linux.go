@@ -0,0 +1,169 @@
+//go:build linux
+// +build linux
+
+package events
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/journal"
+	"github.com/coreos/go-systemd/sdjournal"
+	"github.com/pkg/errors"
+)
+
+// podmanLabelFieldPrefix is prepended to a label's key to form the journal
+// field Write stores it under, mirroring the PODMAN_-prefixed fields used
+// for the rest of Event
+const podmanLabelFieldPrefix = "PODMAN_LABEL_"
+
+// journaldEventer is an Eventer backed by the local systemd journal. Events
+// are written with PODMAN_-prefixed fields and a SYSLOG_IDENTIFIER of tag,
+// which Read uses to filter the journal down to libpod's own entries
+type journaldEventer struct {
+	tag string
+}
+
+// NewJournaldEventer creates an Eventer that writes to, and reads from, the
+// local systemd journal
+func NewJournaldEventer(tag string) (Eventer, error) {
+	if !journal.Enabled() {
+		return nil, ErrNoJournaldLogging
+	}
+
+	return &journaldEventer{tag: tag}, nil
+}
+
+// Write sends event to the journal as a single sd_journal_send entry
+func (e *journaldEventer) Write(event Event) error {
+	fields := map[string]string{
+		"SYSLOG_IDENTIFIER": e.tag,
+		"PODMAN_ID":         event.ID,
+		"PODMAN_NAME":       event.Name,
+		"PODMAN_TYPE":       string(event.Type),
+		"PODMAN_STATUS":     string(event.Status),
+		"PODMAN_TIME":       event.Time.Format(time.RFC3339Nano),
+	}
+	for k, v := range event.Labels {
+		fields[podmanLabelFieldPrefix+k] = v
+	}
+
+	return journal.Send(string(event.Status), journal.PriInfo, fields)
+}
+
+// Read filters the journal down to entries with SYSLOG_IDENTIFIER=e.tag and
+// delivers them in order, following the journal for new entries if
+// options.Stream is set
+func (e *journaldEventer) Read(ctx context.Context, options ReadOptions) (<-chan Event, error) {
+	j, err := sdjournal.NewJournal()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening journal")
+	}
+
+	if err := j.AddMatch("SYSLOG_IDENTIFIER=" + e.tag); err != nil {
+		j.Close()
+		return nil, errors.Wrapf(err, "error filtering journal to %s entries", e.tag)
+	}
+
+	if err := j.SeekHead(); err != nil {
+		j.Close()
+		return nil, errors.Wrapf(err, "error seeking journal to start")
+	}
+
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+		defer j.Close()
+
+		for {
+			n, err := j.Next()
+			if err != nil {
+				return
+			}
+
+			if n == 0 {
+				if !options.Stream {
+					return
+				}
+				if j.Wait(pollInterval) == sdjournal.SD_JOURNAL_NOP {
+					select {
+					case <-ctx.Done():
+						return
+					default:
+					}
+				}
+				continue
+			}
+
+			event, err := eventFromJournalEntry(j)
+			if err != nil {
+				continue
+			}
+
+			if !matches(event, options.Filters) {
+				continue
+			}
+
+			select {
+			case out <- *event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// eventFromJournalEntry reconstructs an Event from the PODMAN_-prefixed
+// fields of the journal entry j currently points to
+func eventFromJournalEntry(j *sdjournal.Journal) (*Event, error) {
+	event := new(Event)
+
+	id, err := j.GetDataValue("PODMAN_ID")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading PODMAN_ID field")
+	}
+	event.ID = id
+
+	if name, err := j.GetDataValue("PODMAN_NAME"); err == nil {
+		event.Name = name
+	}
+	if typ, err := j.GetDataValue("PODMAN_TYPE"); err == nil {
+		event.Type = Type(typ)
+	}
+	if status, err := j.GetDataValue("PODMAN_STATUS"); err == nil {
+		event.Status = Status(status)
+	}
+	if timeStr, err := j.GetDataValue("PODMAN_TIME"); err == nil {
+		if t, err := time.Parse(time.RFC3339Nano, timeStr); err == nil {
+			event.Time = t
+		}
+	}
+
+	usec, err := j.GetRealtimeUsec()
+	if err == nil && event.Time.IsZero() {
+		event.Time = time.Unix(0, int64(usec)*int64(time.Microsecond))
+	}
+
+	// PODMAN_LABEL_ fields have a dynamic, per-label suffix, so they can't
+	// be read with GetDataValue like the fixed fields above - the whole
+	// entry has to be fetched and filtered instead
+	entry, err := j.GetEntry()
+	if err == nil {
+		for field, value := range entry.Fields {
+			label := strings.TrimPrefix(field, podmanLabelFieldPrefix)
+			if label == field {
+				continue
+			}
+			if event.Labels == nil {
+				event.Labels = make(map[string]string)
+			}
+			event.Labels[label] = value
+		}
+	}
+
+	return event, nil
+}
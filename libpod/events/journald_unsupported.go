@@ -0,0 +1,9 @@
+// +build !linux
+
+package events
+
+// NewJournaldEventer is unavailable on platforms other than Linux, since it
+// is backed by the systemd journal
+func NewJournaldEventer(tag string) (Eventer, error) {
+	return nil, ErrOSNotSupported
+}
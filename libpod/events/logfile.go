@@ -0,0 +1,158 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultRotationThreshold is the size, in bytes, a LogFileEventer's log
+// file is allowed to reach before it is rotated aside
+const defaultRotationThreshold = 10 * 1024 * 1024 // 10MB
+
+// pollInterval is how often a streaming Read checks the log file for newly
+// appended events once it has caught up
+const pollInterval = 250 * time.Millisecond
+
+// LogFileEventer is an Eventer backed by a newline-delimited JSON log file
+// on disk, rotated once it exceeds defaultRotationThreshold
+type LogFileEventer struct {
+	lock      sync.Mutex
+	path      string
+	threshold int64
+}
+
+// NewLogFileEventer creates an Eventer that appends events as
+// newline-delimited JSON to the file at path
+func NewLogFileEventer(path string) (*LogFileEventer, error) {
+	return &LogFileEventer{path: path, threshold: defaultRotationThreshold}, nil
+}
+
+// Write appends event to the log file, rotating it first if it has grown
+// past the rotation threshold
+func (e *LogFileEventer) Write(event Event) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.rotateIfNeeded(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error opening event log %s", e.path)
+	}
+	defer f.Close()
+
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling event")
+	}
+	encoded = append(encoded, '\n')
+
+	if _, err := f.Write(encoded); err != nil {
+		return errors.Wrapf(err, "error writing event to %s", e.path)
+	}
+
+	return nil
+}
+
+// rotateIfNeeded renames the current log file aside once it exceeds
+// e.threshold, so Write starts a fresh one. Must be called with e.lock held
+func (e *LogFileEventer) rotateIfNeeded() error {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error statting event log %s", e.path)
+	}
+
+	if info.Size() < e.threshold {
+		return nil
+	}
+
+	return os.Rename(e.path, e.path+".1")
+}
+
+// Read returns events recorded to the log file matching options.Filters. If
+// options.Stream is set, the channel stays open and delivers newly appended
+// events until ctx is canceled. Only the current log file is read: events in
+// a file already rotated aside are not returned, and a streaming Read keeps
+// following the file it opened, so it does not deliver events written to the
+// fresh file that replaces it after a rotation
+func (e *LogFileEventer) Read(ctx context.Context, options ReadOptions) (<-chan Event, error) {
+	f, err := os.Open(e.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "error opening event log %s", e.path)
+		}
+		if !options.Stream {
+			out := make(chan Event)
+			close(out)
+			return out, nil
+		}
+		// No event has been written yet - the goroutine below waits for
+		// the first Write to create the file
+		f = nil
+	}
+
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+
+		for f == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+			if opened, err := os.Open(e.path); err == nil {
+				f = opened
+			}
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for {
+			for scanner.Scan() {
+				var event Event
+				if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+					continue
+				}
+
+				if !matches(&event, options.Filters) {
+					continue
+				}
+
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !options.Stream {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+
+			// bufio.Scanner treats a prior EOF as terminal, so a new
+			// Scanner is needed to pick up data appended since
+			scanner = bufio.NewScanner(f)
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,151 @@
+package filters
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubernetes-incubator/cri-o/libpod"
+	"github.com/pkg/errors"
+)
+
+// GenerateContainerFilterFuncs parses a list of "key=value" filter strings
+// into the ContainerFilter closures accepted by Runtime.Containers, which
+// ANDs them together. A leading "!" on a filter negates it
+//
+// Supported keys are id, name, label, status, pod, volume, exited, since,
+// and until. network, ancestor, and health are recognized but rejected with
+// ErrUnsupportedFilter, since this runtime does not track the network,
+// image, or healthcheck state a container was created with
+func GenerateContainerFilterFuncs(filterStrings []string) ([]libpod.ContainerFilter, error) {
+	filterFuncs := make([]libpod.ContainerFilter, 0, len(filterStrings))
+
+	for _, filterString := range filterStrings {
+		key, value, negate, err := parseFilter(filterString)
+		if err != nil {
+			return nil, err
+		}
+
+		filterFunc, err := containerFilterFromKeyValue(key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if negate {
+			filterFunc = negateContainerFilter(filterFunc)
+		}
+
+		filterFuncs = append(filterFuncs, filterFunc)
+	}
+
+	return filterFuncs, nil
+}
+
+func negateContainerFilter(filter libpod.ContainerFilter) libpod.ContainerFilter {
+	return func(ctr *libpod.Container) bool {
+		return !filter(ctr)
+	}
+}
+
+func containerFilterFromKeyValue(key, value string) (libpod.ContainerFilter, error) {
+	switch key {
+	case "id":
+		return func(ctr *libpod.Container) bool {
+			return strings.HasPrefix(ctr.ID(), value)
+		}, nil
+	case "name":
+		return func(ctr *libpod.Container) bool {
+			return strings.Contains(ctr.Name(), value)
+		}, nil
+	case "label":
+		return containerLabelFilter(value), nil
+	case "status":
+		return containerStatusFilter(value)
+	case "pod":
+		return func(ctr *libpod.Container) bool {
+			pod := ctr.Pod()
+			return pod != nil && (pod.ID() == value || pod.Name() == value)
+		}, nil
+	case "volume":
+		return func(ctr *libpod.Container) bool {
+			for _, volName := range ctr.Volumes() {
+				if volName == value {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "exited":
+		return containerExitedFilter(value)
+	case "since":
+		return containerTimeFilter(value, false)
+	case "until":
+		return containerTimeFilter(value, true)
+	case "network", "ancestor", "health":
+		return nil, errors.Wrapf(ErrUnsupportedFilter, "filter key %q is not supported by this runtime", key)
+	default:
+		return nil, errors.Wrapf(ErrInvalidFilter, "unrecognized filter key %q", key)
+	}
+}
+
+func containerLabelFilter(value string) libpod.ContainerFilter {
+	key, val := splitLabel(value)
+
+	return func(ctr *libpod.Container) bool {
+		spec := ctr.Spec()
+		if spec == nil || spec.Annotations == nil {
+			return false
+		}
+
+		annVal, ok := spec.Annotations[key]
+		if !ok {
+			return false
+		}
+
+		return val == "" || annVal == val
+	}
+}
+
+func containerStatusFilter(value string) (libpod.ContainerFilter, error) {
+	states := map[string]libpod.ContainerState{
+		"created": libpod.ContainerStateConfigured,
+		"running": libpod.ContainerStateRunning,
+		"paused":  libpod.ContainerStatePaused,
+		"exited":  libpod.ContainerStateStopped,
+		"stopped": libpod.ContainerStateStopped,
+	}
+
+	state, ok := states[value]
+	if !ok {
+		return nil, errors.Wrapf(ErrInvalidFilter, "unrecognized status %q", value)
+	}
+
+	return func(ctr *libpod.Container) bool {
+		return ctr.State() == state
+	}, nil
+}
+
+func containerExitedFilter(value string) (libpod.ContainerFilter, error) {
+	code, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidFilter, "exited filter value %q is not an integer", value)
+	}
+
+	return func(ctr *libpod.Container) bool {
+		return ctr.State() == libpod.ContainerStateStopped && ctr.ExitCode() == int32(code)
+	}, nil
+}
+
+func containerTimeFilter(value string, until bool) (libpod.ContainerFilter, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidFilter, "time filter value %q is not RFC3339", value)
+	}
+
+	return func(ctr *libpod.Container) bool {
+		if until {
+			return ctr.CreatedTime().Before(t)
+		}
+		return ctr.CreatedTime().After(t)
+	}, nil
+}
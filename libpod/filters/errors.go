@@ -0,0 +1,13 @@
+package filters
+
+import "errors"
+
+var (
+	// ErrInvalidFilter indicates a filter string that could not be
+	// parsed, for example one missing its "=" separator or whose value
+	// is not of the expected type for its key
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrUnsupportedFilter indicates a filter key that is recognized but
+	// cannot be evaluated against the data this runtime tracks
+	ErrUnsupportedFilter = errors.New("filter not supported by this runtime")
+)
@@ -0,0 +1,39 @@
+// Package filters parses "key=value" filter strings - as accepted by the
+// Docker API and CLI - into the ContainerFilter and PodFilter closures
+// consumed by Runtime.Containers and Runtime.Pods
+package filters
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// parseFilter splits a single filter string into its key, value, and
+// whether it was negated with a leading "!"
+func parseFilter(filterString string) (key, value string, negate bool, err error) {
+	s := filterString
+	if strings.HasPrefix(s, "!") {
+		negate = true
+		s = s[1:]
+	}
+
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", false, errors.Wrapf(ErrInvalidFilter, "filter %q must be of the form key=value", filterString)
+	}
+
+	return parts[0], parts[1], negate, nil
+}
+
+// splitLabel splits a label filter's value into a key and, if present, a
+// value to match it against. A value-less label filter matches any
+// container or pod carrying the key, regardless of its value
+func splitLabel(value string) (key, val string) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+
+	return parts[0], ""
+}
@@ -0,0 +1,132 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-incubator/cri-o/libpod"
+	"github.com/pkg/errors"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantKey    string
+		wantValue  string
+		wantNegate bool
+		wantErr    bool
+	}{
+		{name: "simple", input: "status=running", wantKey: "status", wantValue: "running"},
+		{name: "negated", input: "!status=running", wantKey: "status", wantValue: "running", wantNegate: true},
+		{name: "value contains equals", input: "label=com.example=1", wantKey: "label", wantValue: "com.example=1"},
+		{name: "missing value", input: "status", wantErr: true},
+		{name: "empty key", input: "=running", wantErr: true},
+		{name: "empty string", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, negate, err := parseFilter(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseFilter(%q) returned no error, expected one", tt.input)
+				}
+				if errors.Cause(err) != ErrInvalidFilter {
+					t.Fatalf("parseFilter(%q) error = %v, want ErrInvalidFilter", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFilter(%q) unexpected error: %v", tt.input, err)
+			}
+			if key != tt.wantKey || value != tt.wantValue || negate != tt.wantNegate {
+				t.Fatalf("parseFilter(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.input, key, value, negate, tt.wantKey, tt.wantValue, tt.wantNegate)
+			}
+		})
+	}
+}
+
+func TestSplitLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantKey   string
+		wantValue string
+	}{
+		{name: "key only", input: "com.example.foo", wantKey: "com.example.foo", wantValue: ""},
+		{name: "key and value", input: "com.example.foo=bar", wantKey: "com.example.foo", wantValue: "bar"},
+		{name: "value contains equals", input: "com.example.foo=bar=baz", wantKey: "com.example.foo", wantValue: "bar=baz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value := splitLabel(tt.input)
+			if key != tt.wantKey || value != tt.wantValue {
+				t.Fatalf("splitLabel(%q) = (%q, %q), want (%q, %q)", tt.input, key, value, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestNegateContainerFilter(t *testing.T) {
+	alwaysTrue := func(ctr *libpod.Container) bool { return true }
+
+	negated := negateContainerFilter(alwaysTrue)
+	if negated(nil) {
+		t.Fatal("negateContainerFilter(alwaysTrue) matched, want no match")
+	}
+
+	doubleNegated := negateContainerFilter(negated)
+	if !doubleNegated(nil) {
+		t.Fatal("negating twice did not restore the original result")
+	}
+}
+
+func TestNegatePodFilter(t *testing.T) {
+	alwaysTrue := func(pod *libpod.Pod) bool { return true }
+
+	negated := negatePodFilter(alwaysTrue)
+	if negated(nil) {
+		t.Fatal("negatePodFilter(alwaysTrue) matched, want no match")
+	}
+}
+
+func TestContainerTimeFilter(t *testing.T) {
+	if _, err := containerTimeFilter("not-a-time", false); err == nil {
+		t.Fatal("containerTimeFilter accepted a non-RFC3339 value, want error")
+	} else if errors.Cause(err) != ErrInvalidFilter {
+		t.Fatalf("containerTimeFilter error = %v, want ErrInvalidFilter", err)
+	}
+
+	if _, err := containerTimeFilter(time.Now().Format(time.RFC3339), false); err != nil {
+		t.Fatalf("containerTimeFilter rejected a valid RFC3339 value: %v", err)
+	}
+}
+
+func TestContainerExitedFilter(t *testing.T) {
+	if _, err := containerExitedFilter("not-a-number"); err == nil {
+		t.Fatal("containerExitedFilter accepted a non-integer value, want error")
+	} else if errors.Cause(err) != ErrInvalidFilter {
+		t.Fatalf("containerExitedFilter error = %v, want ErrInvalidFilter", err)
+	}
+
+	if _, err := containerExitedFilter("137"); err != nil {
+		t.Fatalf("containerExitedFilter rejected a valid exit code: %v", err)
+	}
+}
+
+func TestContainerStatusFilter(t *testing.T) {
+	if _, err := containerStatusFilter("not-a-status"); err == nil {
+		t.Fatal("containerStatusFilter accepted an unrecognized status, want error")
+	} else if errors.Cause(err) != ErrInvalidFilter {
+		t.Fatalf("containerStatusFilter error = %v, want ErrInvalidFilter", err)
+	}
+
+	for _, status := range []string{"created", "running", "paused", "exited", "stopped"} {
+		if _, err := containerStatusFilter(status); err != nil {
+			t.Fatalf("containerStatusFilter(%q) unexpected error: %v", status, err)
+		}
+	}
+}
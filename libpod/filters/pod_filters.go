@@ -0,0 +1,63 @@
+package filters
+
+import (
+	"strings"
+
+	"github.com/kubernetes-incubator/cri-o/libpod"
+	"github.com/pkg/errors"
+)
+
+// GeneratePodFilterFuncs parses a list of "key=value" filter strings into the
+// PodFilter closures accepted by Runtime.Pods, which ANDs them together. A
+// leading "!" on a filter negates it
+//
+// Only id and name are supported, as PodConfig tracks nothing else a filter
+// could usefully match against. label, status, network, ancestor, exited,
+// since, until, and health are recognized but rejected with
+// ErrUnsupportedFilter
+func GeneratePodFilterFuncs(filterStrings []string) ([]libpod.PodFilter, error) {
+	filterFuncs := make([]libpod.PodFilter, 0, len(filterStrings))
+
+	for _, filterString := range filterStrings {
+		key, value, negate, err := parseFilter(filterString)
+		if err != nil {
+			return nil, err
+		}
+
+		filterFunc, err := podFilterFromKeyValue(key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if negate {
+			filterFunc = negatePodFilter(filterFunc)
+		}
+
+		filterFuncs = append(filterFuncs, filterFunc)
+	}
+
+	return filterFuncs, nil
+}
+
+func negatePodFilter(filter libpod.PodFilter) libpod.PodFilter {
+	return func(pod *libpod.Pod) bool {
+		return !filter(pod)
+	}
+}
+
+func podFilterFromKeyValue(key, value string) (libpod.PodFilter, error) {
+	switch key {
+	case "id":
+		return func(pod *libpod.Pod) bool {
+			return strings.HasPrefix(pod.ID(), value)
+		}, nil
+	case "name":
+		return func(pod *libpod.Pod) bool {
+			return strings.Contains(pod.Name(), value)
+		}, nil
+	case "label", "status", "network", "ancestor", "exited", "since", "until", "health":
+		return nil, errors.Wrapf(ErrUnsupportedFilter, "filter key %q is not supported by this runtime", key)
+	default:
+		return nil, errors.Wrapf(ErrInvalidFilter, "unrecognized filter key %q", key)
+	}
+}
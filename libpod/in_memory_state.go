@@ -0,0 +1,475 @@
+package libpod
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// InMemoryState is a purely in-memory implementation of the State interface
+// It does not persist state across restarts, and cannot be shared between
+// separate processes
+type InMemoryState struct {
+	lock sync.RWMutex
+
+	containers map[string]*Container
+	pods       map[string]*Pod
+
+	// podContainers maps a pod ID to the set of container IDs that
+	// belong to it
+	podContainers map[string]map[string]*Container
+
+	// ctrDependents maps a container ID to the set of IDs of containers
+	// that depend on it, the reverse of Container.config.Dependencies
+	ctrDependents map[string]map[string]struct{}
+
+	volumes map[string]*Volume
+
+	// volumeUsers maps a volume name to the set of IDs of containers that
+	// reference it, the reverse of Container.config.Volumes
+	volumeUsers map[string]map[string]struct{}
+}
+
+// NewInMemoryState creates a new, empty in-memory state
+func NewInMemoryState() (State, error) {
+	state := new(InMemoryState)
+
+	state.containers = make(map[string]*Container)
+	state.pods = make(map[string]*Pod)
+	state.podContainers = make(map[string]map[string]*Container)
+	state.ctrDependents = make(map[string]map[string]struct{})
+	state.volumes = make(map[string]*Volume)
+	state.volumeUsers = make(map[string]map[string]struct{})
+
+	return state, nil
+}
+
+// Close is a no-op for the in-memory state, as there is nothing to flush to
+// disk
+func (s *InMemoryState) Close() error {
+	return nil
+}
+
+// AddContainer adds a container to the in-memory state
+func (s *InMemoryState) AddContainer(ctr *Container) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.containers[ctr.ID()]; ok {
+		return errors.Wrapf(ErrCtrExists, "container with ID %s already exists in state", ctr.ID())
+	}
+
+	if ctr.config.PodID != "" {
+		pod, ok := s.pods[ctr.config.PodID]
+		if !ok {
+			return errors.Wrapf(ErrNoSuchPod, "pod with ID %s does not exist in state", ctr.config.PodID)
+		}
+		if err := pod.addContainer(ctr); err != nil {
+			return err
+		}
+		s.podContainers[pod.ID()][ctr.ID()] = ctr
+	}
+
+	for _, dep := range ctr.config.Dependencies {
+		if _, ok := s.containers[dep]; !ok {
+			return errors.Wrapf(ErrNoSuchCtr, "dependency container %s does not exist in state", dep)
+		}
+		if s.ctrDependents[dep] == nil {
+			s.ctrDependents[dep] = make(map[string]struct{})
+		}
+		s.ctrDependents[dep][ctr.ID()] = struct{}{}
+	}
+
+	for _, volName := range ctr.config.Volumes {
+		if _, ok := s.volumes[volName]; !ok {
+			return errors.Wrapf(ErrNoSuchVolume, "volume %s does not exist in state", volName)
+		}
+		if s.volumeUsers[volName] == nil {
+			s.volumeUsers[volName] = make(map[string]struct{})
+		}
+		s.volumeUsers[volName][ctr.ID()] = struct{}{}
+	}
+
+	s.containers[ctr.ID()] = ctr
+
+	return nil
+}
+
+// RemoveContainer removes a container from the in-memory state
+func (s *InMemoryState) RemoveContainer(ctr *Container) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.removeContainer(ctr)
+}
+
+// removeContainer performs the actual work of RemoveContainer, minus
+// locking - callers must already hold s.lock
+func (s *InMemoryState) removeContainer(ctr *Container) error {
+	if _, ok := s.containers[ctr.ID()]; !ok {
+		return errors.Wrapf(ErrNoSuchCtr, "container with ID %s does not exist in state", ctr.ID())
+	}
+
+	if ctr.config.PodID != "" {
+		delete(s.podContainers[ctr.config.PodID], ctr.ID())
+	}
+
+	for _, dep := range ctr.config.Dependencies {
+		delete(s.ctrDependents[dep], ctr.ID())
+	}
+	delete(s.ctrDependents, ctr.ID())
+
+	for _, volName := range ctr.config.Volumes {
+		delete(s.volumeUsers[volName], ctr.ID())
+	}
+
+	delete(s.containers, ctr.ID())
+
+	return nil
+}
+
+// UpdateContainer is a no-op for the in-memory state, as the container
+// object given to callers is always the authoritative copy
+func (s *InMemoryState) UpdateContainer(ctr *Container) error {
+	return nil
+}
+
+// SaveContainer is a no-op for the in-memory state, as the container object
+// given to callers is always the authoritative copy
+func (s *InMemoryState) SaveContainer(ctr *Container) error {
+	return nil
+}
+
+// GetContainer retrieves a container from the in-memory state by full ID
+func (s *InMemoryState) GetContainer(id string) (*Container, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ctr, ok := s.containers[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchCtr, "container with ID %s not found", id)
+	}
+
+	return ctr, nil
+}
+
+// HasContainer checks if a container with the given ID is present in the
+// in-memory state
+func (s *InMemoryState) HasContainer(id string) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.containers[id]
+
+	return ok, nil
+}
+
+// LookupContainer retrieves a container by full ID, partial ID, or name
+func (s *InMemoryState) LookupContainer(idOrName string) (*Container, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if idOrName == "" {
+		return nil, ErrEmptyID
+	}
+
+	var match *Container
+	for _, ctr := range s.containers {
+		if ctr.Name() == idOrName || ctr.ID() == idOrName || strings.HasPrefix(ctr.ID(), idOrName) {
+			if match != nil {
+				return nil, errors.Wrapf(ErrCtrExists, "more than one result for ID or name %s", idOrName)
+			}
+			match = ctr
+		}
+	}
+
+	if match == nil {
+		return nil, errors.Wrapf(ErrNoSuchCtr, "no container with name or ID %s found", idOrName)
+	}
+
+	return match, nil
+}
+
+// GetAllContainers retrieves all containers in the in-memory state
+func (s *InMemoryState) GetAllContainers() ([]*Container, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ctrs := make([]*Container, 0, len(s.containers))
+	for _, ctr := range s.containers {
+		ctrs = append(ctrs, ctr)
+	}
+
+	return ctrs, nil
+}
+
+// ContainerInUse returns the IDs of containers that depend on the given
+// container
+func (s *InMemoryState) ContainerInUse(ctr *Container) ([]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	dependents := s.ctrDependents[ctr.ID()]
+	depIDs := make([]string, 0, len(dependents))
+	for id := range dependents {
+		depIDs = append(depIDs, id)
+	}
+
+	return depIDs, nil
+}
+
+// AddPod adds a pod to the in-memory state
+func (s *InMemoryState) AddPod(pod *Pod) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.pods[pod.ID()]; ok {
+		return errors.Wrapf(ErrPodExists, "pod with ID %s already exists in state", pod.ID())
+	}
+
+	s.pods[pod.ID()] = pod
+	s.podContainers[pod.ID()] = make(map[string]*Container)
+
+	return nil
+}
+
+// RemovePod removes a pod from the in-memory state
+func (s *InMemoryState) RemovePod(pod *Pod) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.removePod(pod)
+}
+
+// removePod performs the actual work of RemovePod, minus locking - callers
+// must already hold s.lock
+func (s *InMemoryState) removePod(pod *Pod) error {
+	if _, ok := s.pods[pod.ID()]; !ok {
+		return errors.Wrapf(ErrNoSuchPod, "pod with ID %s does not exist in state", pod.ID())
+	}
+
+	if len(s.podContainers[pod.ID()]) != 0 {
+		return errors.Wrapf(ErrCtrExists, "pod %s still has containers present, cannot remove", pod.ID())
+	}
+
+	delete(s.podContainers, pod.ID())
+	delete(s.pods, pod.ID())
+
+	return nil
+}
+
+// RemovePodContainers removes a pod's member containers and the pod itself
+// from the in-memory state as a single operation under one lock acquisition,
+// so a caller that has already torn every container down outside the OCI
+// runtime never observes - or leaves behind, if it fails partway - a state
+// where only some of a pod's containers have been removed
+func (s *InMemoryState) RemovePodContainers(pod *Pod, ctrs []*Container) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, ctr := range ctrs {
+		if err := s.removeContainer(ctr); err != nil {
+			return errors.Wrapf(err, "error removing container %s from pod %s", ctr.ID(), pod.ID())
+		}
+	}
+
+	return s.removePod(pod)
+}
+
+// SavePod is a no-op for the in-memory state, as the pod object given to
+// callers is always the authoritative copy
+func (s *InMemoryState) SavePod(pod *Pod) error {
+	return nil
+}
+
+// GetPod retrieves a pod from the in-memory state by full ID
+func (s *InMemoryState) GetPod(id string) (*Pod, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	pod, ok := s.pods[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchPod, "pod with ID %s not found", id)
+	}
+
+	return pod, nil
+}
+
+// HasPod checks if a pod with the given ID is present in the in-memory
+// state
+func (s *InMemoryState) HasPod(id string) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.pods[id]
+
+	return ok, nil
+}
+
+// LookupPod retrieves a pod by full ID, partial ID, or name
+func (s *InMemoryState) LookupPod(idOrName string) (*Pod, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if idOrName == "" {
+		return nil, ErrEmptyID
+	}
+
+	var match *Pod
+	for _, pod := range s.pods {
+		if pod.Name() == idOrName || pod.ID() == idOrName || strings.HasPrefix(pod.ID(), idOrName) {
+			if match != nil {
+				return nil, errors.Wrapf(ErrPodExists, "more than one result for ID or name %s", idOrName)
+			}
+			match = pod
+		}
+	}
+
+	if match == nil {
+		return nil, errors.Wrapf(ErrNoSuchPod, "no pod with name or ID %s found", idOrName)
+	}
+
+	return match, nil
+}
+
+// GetAllPods retrieves all pods in the in-memory state
+func (s *InMemoryState) GetAllPods() ([]*Pod, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	pods := make([]*Pod, 0, len(s.pods))
+	for _, pod := range s.pods {
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// PodContainers retrieves all containers belonging to a given pod
+func (s *InMemoryState) PodContainers(pod *Pod) ([]*Container, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	podCtrs, ok := s.podContainers[pod.ID()]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchPod, "pod with ID %s not found", pod.ID())
+	}
+
+	ctrs := make([]*Container, 0, len(podCtrs))
+	for _, ctr := range podCtrs {
+		ctrs = append(ctrs, ctr)
+	}
+
+	return ctrs, nil
+}
+
+// AddVolume adds a volume to the in-memory state
+func (s *InMemoryState) AddVolume(volume *Volume) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.volumes[volume.Name()]; ok {
+		return errors.Wrapf(ErrVolumeExists, "volume with name %s already exists in state", volume.Name())
+	}
+
+	s.volumes[volume.Name()] = volume
+
+	return nil
+}
+
+// RemoveVolume removes a volume from the in-memory state
+func (s *InMemoryState) RemoveVolume(volume *Volume) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		return errors.Wrapf(ErrNoSuchVolume, "volume with name %s does not exist in state", volume.Name())
+	}
+
+	delete(s.volumes, volume.Name())
+	delete(s.volumeUsers, volume.Name())
+
+	return nil
+}
+
+// GetVolume retrieves a volume from the in-memory state by name
+func (s *InMemoryState) GetVolume(name string) (*Volume, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	vol, ok := s.volumes[name]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchVolume, "volume with name %s not found", name)
+	}
+
+	return vol, nil
+}
+
+// LookupVolume retrieves a volume by full name or partial name
+func (s *InMemoryState) LookupVolume(name string) (*Volume, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if name == "" {
+		return nil, ErrEmptyID
+	}
+
+	if vol, ok := s.volumes[name]; ok {
+		return vol, nil
+	}
+
+	var match *Volume
+	for volName, vol := range s.volumes {
+		if strings.HasPrefix(volName, name) {
+			if match != nil {
+				return nil, errors.Wrapf(ErrVolumeExists, "more than one result for name %s", name)
+			}
+			match = vol
+		}
+	}
+
+	if match == nil {
+		return nil, errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found", name)
+	}
+
+	return match, nil
+}
+
+// HasVolume checks if a volume with the given name is present in the
+// in-memory state
+func (s *InMemoryState) HasVolume(name string) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.volumes[name]
+
+	return ok, nil
+}
+
+// GetAllVolumes retrieves all volumes in the in-memory state
+func (s *InMemoryState) GetAllVolumes() ([]*Volume, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	vols := make([]*Volume, 0, len(s.volumes))
+	for _, vol := range s.volumes {
+		vols = append(vols, vol)
+	}
+
+	return vols, nil
+}
+
+// VolumeInUse returns the IDs of containers that reference the given volume
+func (s *InMemoryState) VolumeInUse(volume *Volume) ([]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	users := s.volumeUsers[volume.Name()]
+	userIDs := make([]string, 0, len(users))
+	for id := range users {
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, nil
+}
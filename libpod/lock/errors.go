@@ -0,0 +1,20 @@
+package lock
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNoSuchLock indicates that the requested lock does not exist
+	ErrNoSuchLock = errors.New("no such lock")
+	// ErrLockExists indicates that a lock with the given ID has already
+	// been allocated
+	ErrLockExists = errors.New("lock already exists")
+	// ErrOSNotSupported indicates that this lock backend is not
+	// available on the current platform
+	ErrOSNotSupported = errors.New("not supported on this platform")
+	// ErrSegmentExists indicates that NewSHMLockManager's backing segment
+	// was already created, most likely by another process - callers
+	// should retry with GetSHMLockManager to attach to it instead
+	ErrSegmentExists = errors.New("lock segment already exists")
+)
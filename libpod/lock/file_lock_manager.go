@@ -0,0 +1,148 @@
+package lock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// FileLockManager is a Manager that allocates one file per lock in a
+// directory and synchronizes using flock(2). It is used as a fallback on
+// platforms where the SHM-backed manager's robust pthread mutexes are not
+// available. As with SHMLockManager, a lock held by a process that is killed
+// is not lost: the kernel releases a flock(2) lock once the file descriptor
+// holding it is closed, which happens when the process exits
+type FileLockManager struct {
+	lockPath string
+	numLocks uint32
+
+	// mutex serializes allocating and freeing locks within this process.
+	// Across processes, a lock is marked allocated by creating its .alloc
+	// file with O_EXCL, so no two processes can allocate the same lock
+	mutex sync.Mutex
+}
+
+// NewFileLockManager creates a new directory of lock files at the given
+// path, with the given number of available locks
+func NewFileLockManager(lockPath string, numLocks uint32) (Manager, error) {
+	if numLocks == 0 {
+		return nil, errors.Wrapf(ErrNoSuchLock, "must provide a non-zero number of locks")
+	}
+
+	if err := os.MkdirAll(lockPath, 0711); err != nil {
+		return nil, errors.Wrapf(err, "error creating lock directory %s", lockPath)
+	}
+
+	manager := &FileLockManager{
+		lockPath: lockPath,
+		numLocks: numLocks,
+	}
+
+	for i := uint32(0); i < numLocks; i++ {
+		f, err := os.OpenFile(manager.lockFilePath(i), os.O_CREATE|os.O_RDWR, 0600)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating lock file for lock %d", i)
+		}
+		f.Close()
+	}
+
+	return manager, nil
+}
+
+func (m *FileLockManager) lockFilePath(id uint32) string {
+	return filepath.Join(m.lockPath, fmt.Sprintf("%d.lock", id))
+}
+
+func (m *FileLockManager) allocFilePath(id uint32) string {
+	return filepath.Join(m.lockPath, fmt.Sprintf("%d.alloc", id))
+}
+
+// AllocateLock allocates the first lock file not already marked in use
+func (m *FileLockManager) AllocateLock() (Locker, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i := uint32(0); i < m.numLocks; i++ {
+		if _, err := os.Stat(m.allocFilePath(i)); os.IsNotExist(err) {
+			f, err := os.OpenFile(m.allocFilePath(i), os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error marking lock %d as allocated", i)
+			}
+			f.Close()
+
+			return &FileLock{id: i, path: m.lockFilePath(i), fd: -1}, nil
+		}
+	}
+
+	return nil, errors.Wrapf(ErrNoSuchLock, "no free locks available, all %d in use", m.numLocks)
+}
+
+// RetrieveLock retrieves a lock by ID, regardless of whether it has been
+// allocated
+func (m *FileLockManager) RetrieveLock(id uint32) (Locker, error) {
+	if id >= m.numLocks {
+		return nil, errors.Wrapf(ErrNoSuchLock, "lock ID %d is out of range, max is %d", id, m.numLocks-1)
+	}
+
+	return &FileLock{id: id, path: m.lockFilePath(id), fd: -1}, nil
+}
+
+// FreeLock marks a lock as no longer in use, allowing it to be reallocated
+func (m *FileLockManager) FreeLock(id uint32) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if id >= m.numLocks {
+		return errors.Wrapf(ErrNoSuchLock, "lock ID %d is out of range, max is %d", id, m.numLocks-1)
+	}
+
+	if err := os.Remove(m.allocFilePath(id)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error freeing lock %d", id)
+	}
+
+	return nil
+}
+
+// FileLock is a single lock backed by flock(2) on a regular file. The lock
+// is held by keeping the file descriptor used to acquire it open between
+// Lock and Unlock, since flock(2) releases a lock when its file description
+// is closed
+type FileLock struct {
+	id   uint32
+	path string
+	fd   int
+}
+
+// Lock locks the file, blocking until it is available
+func (l *FileLock) Lock() {
+	fd, err := unix.Open(l.path, unix.O_CREAT|unix.O_RDWR, 0600)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := unix.Flock(fd, unix.LOCK_EX); err != nil {
+		unix.Close(fd)
+		panic(err)
+	}
+
+	l.fd = fd
+}
+
+// Unlock unlocks the file
+func (l *FileLock) Unlock() {
+	if err := unix.Flock(l.fd, unix.LOCK_UN); err != nil {
+		panic(err)
+	}
+
+	unix.Close(l.fd)
+	l.fd = -1
+}
+
+// ID returns the ID of the lock
+func (l *FileLock) ID() uint32 {
+	return l.id
+}
@@ -0,0 +1,31 @@
+// Package lock provides synchronization primitives that work across
+// multiple processes sharing the same libpod state, as a replacement for
+// the per-process sync.Mutex used previously
+package lock
+
+// Locker is similar to sync.Locker, but also provides the lock's ID within
+// its manager, which is what Manager.FreeLock takes once the lock is no
+// longer in use and can be safely reallocated
+type Locker interface {
+	// Lock locks the lock
+	Lock()
+	// Unlock unlocks the lock
+	Unlock()
+	// ID returns the ID of the lock within its manager
+	ID() uint32
+}
+
+// Manager allocates and retrieves locks for use by containers and pods. A
+// single Manager is backed by a fixed number of locks set at creation time;
+// callers must free a lock when the object it protects is removed so the
+// slot can be reused
+type Manager interface {
+	// AllocateLock allocates a new lock from the manager's pool of free
+	// locks and returns it
+	AllocateLock() (Locker, error)
+	// RetrieveLock retrieves a previously-allocated lock by its ID
+	RetrieveLock(id uint32) (Locker, error)
+	// FreeLock frees a lock that is no longer needed, returning it to
+	// the pool of locks available for allocation
+	FreeLock(id uint32) error
+}
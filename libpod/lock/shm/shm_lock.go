@@ -0,0 +1,193 @@
+//go:build linux && cgo
+// +build linux,cgo
+
+// Package shm provides a fixed-size pool of pthread_mutex_t locks backed by
+// a POSIX shared memory segment, so unrelated processes can take out locks
+// on the same numbered slot and see each other's state
+package shm
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// #cgo LDFLAGS: -lpthread
+// #include <stdlib.h>
+// #include "shm_lock.h"
+import "C"
+
+// ErrSegmentExists indicates that the SHM segment CreateSHMLock would have
+// created already exists, most likely because another process created it
+// first. Callers should use OpenSHMLock to attach to it instead
+var ErrSegmentExists = errors.New("SHM segment already exists")
+
+// SHMLocks is a pool of POSIX semaphores backed by a shared memory segment.
+// Each semaphore is a pthread_mutex_t configured with PTHREAD_PROCESS_SHARED
+// and PTHREAD_MUTEX_ROBUST, so a lock held by a process that dies is
+// automatically marked inconsistent and can be recovered by the next
+// locker instead of deadlocking forever
+type SHMLocks struct {
+	lockStruct *C.shm_struct_t
+	maxLocks   uint32
+	valid      bool
+
+	// mutex guards opening/closing the segment itself; the individual
+	// locks within it are synchronized by the kernel via the underlying
+	// pthread mutexes
+	mutex sync.Mutex
+}
+
+// CreateSHMLock creates a new SHM segment with the given number of locks,
+// initializing all of them to unlocked and unallocated. It must only be
+// called once per segment; other processes sharing the lock struct should
+// use OpenSHMLock
+func CreateSHMLock(path string, numLocks uint32) (*SHMLocks, error) {
+	if numLocks == 0 {
+		return nil, errors.Errorf("must provide a non-zero number of locks to create")
+	}
+
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var errCode C.int
+	lockStruct := C.setup_lock_shm(cPath, C.uint32_t(numLocks), &errCode)
+	if lockStruct == nil {
+		if syscall.Errno(errCode) == syscall.EEXIST {
+			return nil, errors.Wrapf(ErrSegmentExists, "error creating SHM lock struct")
+		}
+		return nil, errors.Wrapf(syscallError(errCode), "error creating SHM lock struct")
+	}
+
+	locks := new(SHMLocks)
+	locks.lockStruct = lockStruct
+	locks.maxLocks = numLocks
+	locks.valid = true
+
+	return locks, nil
+}
+
+// OpenSHMLock opens an existing SHM segment created by CreateSHMLock,
+// verifying that its size matches the given number of locks
+func OpenSHMLock(path string, numLocks uint32) (*SHMLocks, error) {
+	if numLocks == 0 {
+		return nil, errors.Errorf("must provide a non-zero number of locks to open")
+	}
+
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var errCode C.int
+	lockStruct := C.open_lock_shm(cPath, C.uint32_t(numLocks), &errCode)
+	if lockStruct == nil {
+		return nil, errors.Wrapf(syscallError(errCode), "error opening SHM lock struct")
+	}
+
+	locks := new(SHMLocks)
+	locks.lockStruct = lockStruct
+	locks.maxLocks = numLocks
+	locks.valid = true
+
+	return locks, nil
+}
+
+// GetMaxLocks returns the number of locks in the segment
+func (locks *SHMLocks) GetMaxLocks() uint32 {
+	return locks.maxLocks
+}
+
+// Close closes the SHM segment, unmapping it from this process's memory.
+// Other processes holding the segment open are unaffected
+func (locks *SHMLocks) Close() error {
+	locks.mutex.Lock()
+	defer locks.mutex.Unlock()
+
+	if !locks.valid {
+		return nil
+	}
+
+	if retCode := C.close_lock_shm(locks.lockStruct); retCode != 0 {
+		return errors.Wrapf(syscallError(retCode), "error closing SHM lock struct")
+	}
+
+	locks.valid = false
+
+	return nil
+}
+
+// AllocateSemaphore allocates a free semaphore from the segment's bitmap,
+// returning its index for later use with LockSemaphore/UnlockSemaphore
+func (locks *SHMLocks) AllocateSemaphore() (uint32, error) {
+	if !locks.valid {
+		return 0, errors.Errorf("locks have already been closed")
+	}
+
+	var errCode C.int
+	retCode := C.allocate_semaphore(locks.lockStruct, &errCode)
+	if retCode < 0 {
+		return 0, errors.Wrapf(syscallError(errCode), "error allocating semaphore")
+	}
+
+	return uint32(retCode), nil
+}
+
+// DeallocateSemaphore frees a semaphore so it can be allocated again
+func (locks *SHMLocks) DeallocateSemaphore(sem uint32) error {
+	if !locks.valid {
+		return errors.Errorf("locks have already been closed")
+	}
+
+	if sem >= locks.maxLocks {
+		return errors.Errorf("semaphore ID %d is too large - max is %d", sem, locks.maxLocks-1)
+	}
+
+	if retCode := C.deallocate_semaphore(locks.lockStruct, C.uint32_t(sem)); retCode != 0 {
+		return errors.Wrapf(syscallError(retCode), "error deallocating semaphore %d", sem)
+	}
+
+	return nil
+}
+
+// LockSemaphore locks the given semaphore, blocking until it is free. If the
+// previous holder of the lock died without releasing it, the underlying
+// robust mutex is automatically marked consistent again and the call
+// succeeds
+func (locks *SHMLocks) LockSemaphore(sem uint32) error {
+	if !locks.valid {
+		return errors.Errorf("locks have already been closed")
+	}
+
+	if sem >= locks.maxLocks {
+		return errors.Errorf("semaphore ID %d is too large - max is %d", sem, locks.maxLocks-1)
+	}
+
+	if retCode := C.lock_semaphore(locks.lockStruct, C.uint32_t(sem)); retCode != 0 {
+		return errors.Wrapf(syscallError(retCode), "error locking semaphore %d", sem)
+	}
+
+	return nil
+}
+
+// UnlockSemaphore unlocks the given semaphore
+func (locks *SHMLocks) UnlockSemaphore(sem uint32) error {
+	if !locks.valid {
+		return errors.Errorf("locks have already been closed")
+	}
+
+	if sem >= locks.maxLocks {
+		return errors.Errorf("semaphore ID %d is too large - max is %d", sem, locks.maxLocks-1)
+	}
+
+	if retCode := C.unlock_semaphore(locks.lockStruct, C.uint32_t(sem)); retCode != 0 {
+		return errors.Wrapf(syscallError(retCode), "error unlocking semaphore %d", sem)
+	}
+
+	return nil
+}
+
+func syscallError(errCode C.int) error {
+	return fmt.Errorf(C.GoString(C.strerror(errCode)))
+}
@@ -0,0 +1,102 @@
+//go:build linux && cgo
+// +build linux,cgo
+
+package lock
+
+import (
+	"github.com/kubernetes-incubator/cri-o/libpod/lock/shm"
+	"github.com/pkg/errors"
+)
+
+// SHMLockManager manages a pool of locks backed by a POSIX SHM segment of
+// robust, process-shared pthread mutexes
+type SHMLockManager struct {
+	locks *shm.SHMLocks
+}
+
+// NewSHMLockManager creates a new SHM segment of the given size and a
+// manager for it. It must only be called once per segment - other
+// processes that want to use the same segment should call
+// GetSHMLockManager instead
+func NewSHMLockManager(path string, numLocks uint32) (Manager, error) {
+	locks, err := shm.CreateSHMLock(path, numLocks)
+	if err != nil {
+		if errors.Cause(err) == shm.ErrSegmentExists {
+			return nil, errors.Wrapf(ErrSegmentExists, "error creating SHM lock manager")
+		}
+		return nil, errors.Wrapf(err, "error creating SHM lock manager")
+	}
+
+	manager := new(SHMLockManager)
+	manager.locks = locks
+
+	return manager, nil
+}
+
+// GetSHMLockManager opens an existing SHM segment and returns a manager for
+// it
+func GetSHMLockManager(path string, numLocks uint32) (Manager, error) {
+	locks, err := shm.OpenSHMLock(path, numLocks)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening SHM lock manager")
+	}
+
+	manager := new(SHMLockManager)
+	manager.locks = locks
+
+	return manager, nil
+}
+
+// AllocateLock allocates a new lock from the SHM segment
+func (m *SHMLockManager) AllocateLock() (Locker, error) {
+	semIndex, err := m.locks.AllocateSemaphore()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error allocating lock")
+	}
+
+	return &SHMLock{semIndex, m.locks}, nil
+}
+
+// RetrieveLock retrieves a lock from the SHM segment given its ID
+func (m *SHMLockManager) RetrieveLock(id uint32) (Locker, error) {
+	if id >= m.locks.GetMaxLocks() {
+		return nil, errors.Errorf("lock ID %d is out of range, max is %d", id, m.locks.GetMaxLocks()-1)
+	}
+
+	return &SHMLock{id, m.locks}, nil
+}
+
+// FreeLock frees a lock that is no longer in use so it can be reallocated
+func (m *SHMLockManager) FreeLock(id uint32) error {
+	return m.locks.DeallocateSemaphore(id)
+}
+
+// SHMLock is a single lock within a SHM segment, satisfying the Locker
+// interface
+type SHMLock struct {
+	id    uint32
+	locks *shm.SHMLocks
+}
+
+// Lock locks the semaphore, blocking until it is available
+func (l *SHMLock) Lock() {
+	if err := l.locks.LockSemaphore(l.id); err != nil {
+		// The Locker interface gives Lock() no way to return an
+		// error; a failure here indicates the SHM segment itself is
+		// corrupt or has been closed out from under us, which is not
+		// recoverable
+		panic(err)
+	}
+}
+
+// Unlock unlocks the semaphore
+func (l *SHMLock) Unlock() {
+	if err := l.locks.UnlockSemaphore(l.id); err != nil {
+		panic(err)
+	}
+}
+
+// ID returns the ID of the lock
+func (l *SHMLock) ID() uint32 {
+	return l.id
+}
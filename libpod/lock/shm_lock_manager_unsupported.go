@@ -0,0 +1,19 @@
+// +build !linux !cgo
+
+package lock
+
+import (
+	"github.com/pkg/errors"
+)
+
+// NewSHMLockManager is not supported on this platform - robust,
+// process-shared pthread mutexes are a Linux-specific feature, so callers
+// on other platforms should fall back to NewFileLockManager
+func NewSHMLockManager(path string, numLocks uint32) (Manager, error) {
+	return nil, errors.Wrapf(ErrOSNotSupported, "SHM locks are not supported on this platform")
+}
+
+// GetSHMLockManager is not supported on this platform
+func GetSHMLockManager(path string, numLocks uint32) (Manager, error) {
+	return nil, errors.Wrapf(ErrOSNotSupported, "SHM locks are not supported on this platform")
+}
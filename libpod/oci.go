@@ -0,0 +1,117 @@
+package libpod
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// killContainerTimeout is how long stopContainer waits after sending
+// SIGTERM before giving up and sending SIGKILL
+const killContainerTimeout = 10 * time.Second
+
+// ociRuntime wraps invocations of the OCI runtime and conmon binaries used
+// to manage a container's actual process
+type ociRuntime struct {
+	path       string
+	conmonPath string
+}
+
+// newOCIRuntime creates a new wrapper for invoking the given OCI runtime and
+// conmon binaries
+func newOCIRuntime(path, conmonPath string) (*ociRuntime, error) {
+	if path == "" {
+		return nil, errors.Wrapf(ErrInvalidArg, "OCI runtime path cannot be empty")
+	}
+	if conmonPath == "" {
+		return nil, errors.Wrapf(ErrInvalidArg, "conmon path cannot be empty")
+	}
+
+	runtime := new(ociRuntime)
+	runtime.path = path
+	runtime.conmonPath = conmonPath
+
+	return runtime, nil
+}
+
+// stopContainer stops a running container, first requesting a graceful exit
+// via SIGTERM and then killing it with SIGKILL if it has not exited after
+// killContainerTimeout
+func (o *ociRuntime) stopContainer(ctr *Container) error {
+	if err := o.killContainer(ctr, syscall.SIGTERM); err != nil {
+		return errors.Wrapf(err, "error sending SIGTERM to container %s", ctr.ID())
+	}
+
+	if err := o.waitContainerStopped(ctr, killContainerTimeout); err != nil {
+		if err := o.killContainer(ctr, syscall.SIGKILL); err != nil {
+			return errors.Wrapf(err, "error sending SIGKILL to container %s", ctr.ID())
+		}
+	}
+
+	return nil
+}
+
+// killContainer sends the given signal to a container via the OCI runtime
+func (o *ociRuntime) killContainer(ctr *Container, signal syscall.Signal) error {
+	return exec.Command(o.path, "kill", ctr.ID(), strconvSignal(signal)).Run()
+}
+
+// waitContainerStopped polls the OCI runtime until the container is no
+// longer running, or the given timeout elapses
+func (o *ociRuntime) waitContainerStopped(ctr *Container, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		stopped, err := o.containerStopped(ctr)
+		if err != nil {
+			// The runtime errors out once the container no longer
+			// exists in its view - treat that as stopped
+			return nil
+		}
+		if stopped {
+			return nil
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return errors.Wrapf(ErrCtrStateInvalid, "timed out waiting for container %s to stop", ctr.ID())
+}
+
+// containerStopped queries the OCI runtime's own view of ctr's status. Unlike
+// "runc state"'s exit code - which is 0 for a container in any state, and
+// only nonzero once the container is gone from the runtime's view entirely -
+// this looks at the status it actually reports, so a graceful stop is
+// detected as soon as it happens instead of only once the container has been
+// deleted
+func (o *ociRuntime) containerStopped(ctr *Container) (bool, error) {
+	out, err := exec.Command(o.path, "state", ctr.ID()).Output()
+	if err != nil {
+		return false, err
+	}
+
+	var state struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(out, &state); err != nil {
+		return false, errors.Wrapf(err, "error parsing state of container %s", ctr.ID())
+	}
+
+	return state.Status == "stopped", nil
+}
+
+// deleteContainer removes a container from the OCI runtime, forcibly
+// stopping it first if it is still running
+func (o *ociRuntime) deleteContainer(ctr *Container) error {
+	return exec.Command(o.path, "delete", "--force", ctr.ID()).Run()
+}
+
+// strconvSignal renders a signal as the decimal string the OCI runtime CLI
+// expects
+func strconvSignal(signal syscall.Signal) string {
+	return strconv.Itoa(int(signal))
+}
@@ -0,0 +1,197 @@
+package libpod
+
+import (
+	"github.com/kubernetes-incubator/cri-o/libpod/lock"
+	"github.com/pkg/errors"
+)
+
+// WithStateType selects the backend used to store the Runtime's state
+// If this option is not given, an in-memory state will be used, which does
+// not persist data and cannot be shared between multiple processes
+func WithStateType(stateType RuntimeStateType) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.stateType = stateType
+
+		return nil
+	}
+}
+
+// WithStateDir sets the directory the runtime's persistent state will be
+// stored in. With WithStateType(BoltDBState) it holds the database; with
+// either state type it holds the file event log and, when the file lock
+// manager is used, its lock files
+func WithStateDir(dir string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		if dir == "" {
+			return errors.Wrapf(ErrInvalidArg, "state directory cannot be empty")
+		}
+
+		rt.stateDir = dir
+
+		return nil
+	}
+}
+
+// WithDependencyCtrs marks the given containers as dependencies of the
+// container being created - for example, containers whose namespaces it
+// joins. The container cannot be created unless all dependencies already
+// exist, and cannot be removed while it remains a dependency of another
+// container unless force is specified
+func WithDependencyCtrs(ctrs ...*Container) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		for _, dep := range ctrs {
+			if dep.ID() == ctr.ID() {
+				return errors.Wrapf(ErrInvalidArg, "container cannot depend on itself")
+			}
+			ctr.config.Dependencies = append(ctr.config.Dependencies, dep.ID())
+		}
+
+		return nil
+	}
+}
+
+// WithVolumes marks the given volumes as used by the container being
+// created. The container cannot be created unless all of the volumes
+// already exist, and a volume cannot be removed while it remains in use by a
+// container unless force is specified
+func WithVolumes(volumes ...*Volume) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		for _, vol := range volumes {
+			ctr.config.Volumes = append(ctr.config.Volumes, vol.Name())
+		}
+
+		return nil
+	}
+}
+
+// WithVolumeLabels sets the labels of the volume being created
+func WithVolumeLabels(labels map[string]string) VolumeCreateOption {
+	return func(v *Volume) error {
+		if v.valid {
+			return ErrVolumeFinalized
+		}
+
+		v.config.Labels = make(map[string]string, len(labels))
+		for key, value := range labels {
+			v.config.Labels[key] = value
+		}
+
+		return nil
+	}
+}
+
+// WithVolumeDriverName selects the VolumeDriver used to create and remove
+// the volume's on-disk storage. If this option is not given, the built-in
+// local driver is used
+func WithVolumeDriverName(driver string) VolumeCreateOption {
+	return func(v *Volume) error {
+		if v.valid {
+			return ErrVolumeFinalized
+		}
+
+		if driver == "" {
+			return errors.Wrapf(ErrInvalidArg, "volume driver name cannot be empty")
+		}
+
+		v.config.Driver = driver
+
+		return nil
+	}
+}
+
+// WithVolumeDriver registers an additional VolumeDriver that volumes can be
+// created with via WithVolumeDriverName. A driver named "local" replaces the
+// built-in local driver; any other name leaves the built-in driver
+// registered alongside it
+func WithVolumeDriver(driver VolumeDriver) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		if driver == nil || driver.Name() == "" {
+			return errors.Wrapf(ErrInvalidArg, "volume driver must be non-nil and have a name")
+		}
+
+		if _, ok := rt.volumeDrivers[driver.Name()]; ok {
+			return errors.Wrapf(ErrInvalidArg, "volume driver %s is already registered", driver.Name())
+		}
+
+		rt.volumeDrivers[driver.Name()] = driver
+
+		return nil
+	}
+}
+
+// WithEventsLogger selects the backend used to record lifecycle events for
+// containers and pods. Valid values are "journald", "file", and "none"; if
+// this option is not given, the file backend is used
+func WithEventsLogger(logger string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		switch logger {
+		case "journald", "file", "none":
+			// Valid values, nothing further to check here - NewRuntime
+			// does the actual work of setting up the backend once the
+			// rest of the runtime's configuration is known
+		default:
+			return errors.Wrapf(ErrInvalidArg, "unrecognized events logger %q", logger)
+		}
+
+		rt.eventsLogger = logger
+
+		return nil
+	}
+}
+
+// WithLockManager provides a pre-constructed lock manager for the runtime
+// to use instead of the default SHM-backed (or, where unavailable,
+// file-backed) manager
+func WithLockManager(manager lock.Manager) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.lockManager = manager
+
+		return nil
+	}
+}
+
+// WithNumLocks sets the number of locks the runtime's default lock manager
+// will allocate. It has no effect if WithLockManager is also used
+func WithNumLocks(numLocks uint32) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		if numLocks == 0 {
+			return errors.Wrapf(ErrInvalidArg, "number of locks must be greater than 0")
+		}
+
+		rt.numLocks = numLocks
+
+		return nil
+	}
+}
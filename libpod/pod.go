@@ -0,0 +1,99 @@
+package libpod
+
+import (
+	"github.com/docker/docker/pkg/namesgenerator"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/kubernetes-incubator/cri-o/libpod/lock"
+	"github.com/pkg/errors"
+)
+
+// Pod represents a group of containers that share namespaces
+type Pod struct {
+	config *PodConfig
+
+	containers map[string]*Container
+
+	runtime *Runtime
+	lock    lock.Locker
+	valid   bool
+}
+
+// PodConfig holds the static configuration of a pod
+type PodConfig struct {
+	ID   string
+	Name string
+
+	// InfraContainerID is the ID of the container holding the pod's
+	// shared namespaces, if one exists
+	InfraContainerID string
+
+	// LockID is the ID of the lock used to protect this pod from
+	// concurrent access across processes
+	LockID uint32
+}
+
+// newPod makes a new, empty pod
+func newPod() (*Pod, error) {
+	pod := new(Pod)
+	pod.config = new(PodConfig)
+	pod.containers = make(map[string]*Container)
+
+	pod.config.ID = stringid.GenerateNonCryptoID()
+	pod.config.Name = namesgenerator.GetRandomName(0)
+
+	return pod, nil
+}
+
+// ID returns the pod's ID
+func (p *Pod) ID() string {
+	return p.config.ID
+}
+
+// Name returns the pod's name
+func (p *Pod) Name() string {
+	return p.config.Name
+}
+
+// addContainer adds a container to the pod's list of member containers
+func (p *Pod) addContainer(ctr *Container) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.containers[ctr.ID()]; ok {
+		return errors.Wrapf(ErrCtrExists, "container %s is already part of pod %s", ctr.ID(), p.ID())
+	}
+
+	p.containers[ctr.ID()] = ctr
+	ctr.pod = p
+
+	return nil
+}
+
+// removeContainer removes a container from the pod's list of member
+// containers
+func (p *Pod) removeContainer(ctr *Container) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.containers[ctr.ID()]; !ok {
+		return errors.Wrapf(ErrNoSuchCtr, "container %s is not part of pod %s", ctr.ID(), p.ID())
+	}
+
+	delete(p.containers, ctr.ID())
+	ctr.pod = nil
+
+	return nil
+}
+
+// AllContainers returns all containers in the pod
+func (p *Pod) AllContainers() []*Container {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	ctrs := make([]*Container, 0, len(p.containers))
+	for _, ctr := range p.containers {
+		ctrs = append(ctrs, ctr)
+	}
+
+	return ctrs
+}
@@ -1,10 +1,16 @@
 package libpod
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/containers/image/types"
 	"github.com/containers/storage"
+	"github.com/kubernetes-incubator/cri-o/libpod/events"
+	"github.com/kubernetes-incubator/cri-o/libpod/lock"
 	"github.com/kubernetes-incubator/cri-o/server/apparmor"
 	"github.com/kubernetes-incubator/cri-o/server/seccomp"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -16,16 +22,68 @@ import (
 // NewRuntime
 type RuntimeOption func(*Runtime) error
 
+// RuntimeStateType reflects the type of State backing the Runtime is using
+type RuntimeStateType int
+
+const (
+	// InMemoryState is an in-memory state that does not persist data and
+	// cannot be shared between multiple processes
+	InMemoryState RuntimeStateType = iota
+	// BoltDBState is a BoltDB-backed state that persists to a file on
+	// disk and can safely be shared between multiple processes
+	BoltDBState
+)
+
+// defaultStateDir is used when no WithStateDir option is given
+const defaultStateDir = "/var/lib/containers/storage/libpod"
+
+// boltDBFile is the name of the file the BoltDB state is stored under,
+// inside the runtime's state directory
+const boltDBFile = "bolt_state.db"
+
+// shmLockSegmentName is the name of the POSIX SHM segment used to back the
+// default lock manager
+const shmLockSegmentName = "/libpod_lock"
+
+// defaultNumLocks is the number of locks allocated in a new lock manager if
+// WithNumLocks is not given
+const defaultNumLocks = 2048
+
+// defaultEventsLogger is the event backend used if WithEventsLogger is not
+// given
+const defaultEventsLogger = "file"
+
+// eventsLogFile is the name of the file the file-backed event logger writes
+// to, inside the runtime's state directory
+const eventsLogFile = "events.log"
+
+// journaldEventsTag identifies libpod's own entries in the journal when the
+// journald event backend is in use
+const journaldEventsTag = "cri-o"
+
 // Runtime is the core libpod runtime
 type Runtime struct {
 	config          *RuntimeConfig
 	state           State
 	store           storage.Store
 	imageContext    *types.SystemContext
+	stateType       RuntimeStateType
+	stateDir        string
+	lockManager     lock.Manager
+	numLocks        uint32
+	ociRuntime      *ociRuntime
+	eventsLogger    string
+	eventer         events.Eventer
+	volumeDrivers   map[string]VolumeDriver
 	apparmorEnabled bool
 	seccompEnabled  bool
 	valid           bool
-	lock            sync.RWMutex
+
+	// lock guards only the valid flag above and the Shutdown transition
+	// that flips it. It does not serialize the rest of the runtime's
+	// operations - those rely on the state's own concurrency safety and
+	// on the per-container/per-pod SHM locks allocated from lockManager
+	lock sync.RWMutex
 }
 
 // RuntimeConfig contains configuration options used to set up the runtime
@@ -64,6 +122,9 @@ var (
 func NewRuntime(options ...RuntimeOption) (*Runtime, error) {
 	runtime := new(Runtime)
 	runtime.config = new(RuntimeConfig)
+	runtime.stateType = InMemoryState
+	runtime.numLocks = defaultNumLocks
+	runtime.volumeDrivers = make(map[string]VolumeDriver)
 
 	// Copy the default configuration
 	deepcopier.Copy(defaultRuntimeConfig).To(runtime.config)
@@ -90,6 +151,105 @@ func NewRuntime(options ...RuntimeOption) (*Runtime, error) {
 	runtime.seccompEnabled = seccomp.IsEnabled()
 	runtime.apparmorEnabled = apparmor.IsEnabled()
 
+	if runtime.stateDir == "" {
+		runtime.stateDir = defaultStateDir
+	}
+
+	// Register the built-in local volume driver, unless a driver of the
+	// same name was already registered via WithVolumeDriver
+	if _, ok := runtime.volumeDrivers[localDriverName]; !ok {
+		runtime.volumeDrivers[localDriverName] = newLocalVolumeDriver(filepath.Join(runtime.store.GraphRoot(), "volumes"))
+	}
+
+	// Set up the state backing the runtime
+	switch runtime.stateType {
+	case InMemoryState:
+		state, err := NewInMemoryState()
+		if err != nil {
+			return nil, err
+		}
+		runtime.state = state
+	case BoltDBState:
+		dbPath := filepath.Join(runtime.stateDir, boltDBFile)
+
+		onDiskVersion, err := getSchemaVersion(dbPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error determining schema version of database %s", dbPath)
+		}
+		if onDiskVersion != schemaVersion {
+			return nil, errors.Wrapf(ErrDBBadConfig, "database %s is schema version %d, runtime expects version %d - run Migrate() to upgrade", dbPath, onDiskVersion, schemaVersion)
+		}
+
+		state, err := NewBoltState(dbPath)
+		if err != nil {
+			return nil, err
+		}
+		runtime.state = state
+	default:
+		return nil, errors.Wrapf(ErrInvalidArg, "unrecognized state type %v passed to NewRuntime", runtime.stateType)
+	}
+
+	// Set up the lock manager, unless one was already provided via
+	// WithLockManager
+	if runtime.lockManager == nil {
+		manager, err := lock.NewSHMLockManager(shmLockSegmentName, runtime.numLocks)
+		if err != nil {
+			if errors.Cause(err) == lock.ErrSegmentExists {
+				// Another process already created the segment -
+				// attach to it instead of falling back to a file
+				// lock manager, so locks are still actually shared
+				// with that process rather than silently becoming
+				// two disjoint lock pools
+				attached, err2 := lock.GetSHMLockManager(shmLockSegmentName, runtime.numLocks)
+				if err2 != nil {
+					return nil, errors.Wrapf(err2, "error attaching to existing SHM lock manager")
+				}
+				manager = attached
+			} else {
+				// The SHM lock manager needs cgo and robust pthread
+				// mutexes, neither of which are available on every
+				// platform - fall back to a file-based manager there
+				fileManager, err2 := lock.NewFileLockManager(filepath.Join(runtime.stateDir, "locks"), runtime.numLocks)
+				if err2 != nil {
+					return nil, errors.Wrapf(err, "error creating lock manager")
+				}
+				manager = fileManager
+			}
+		}
+		runtime.lockManager = manager
+	}
+
+	ociRuntime, err := newOCIRuntime(runtime.config.RuntimePath, runtime.config.ConmonPath)
+	if err != nil {
+		return nil, err
+	}
+	runtime.ociRuntime = ociRuntime
+
+	// Set up the event logger, using the default backend unless
+	// WithEventsLogger selected one
+	if runtime.eventsLogger == "" {
+		runtime.eventsLogger = defaultEventsLogger
+	}
+
+	switch runtime.eventsLogger {
+	case "none":
+		runtime.eventer = nil
+	case "journald":
+		eventer, err := events.NewJournaldEventer(journaldEventsTag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating journald event logger")
+		}
+		runtime.eventer = eventer
+	case "file":
+		eventer, err := events.NewLogFileEventer(filepath.Join(runtime.stateDir, eventsLogFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating file event logger")
+		}
+		runtime.eventer = eventer
+	default:
+		return nil, errors.Wrapf(ErrInvalidArg, "unrecognized events logger %q passed to NewRuntime", runtime.eventsLogger)
+	}
+
 	// Mark the runtime as valid - ready to be used, cannot be modified
 	// further
 	runtime.valid = true
@@ -97,12 +257,107 @@ func NewRuntime(options ...RuntimeOption) (*Runtime, error) {
 	return runtime, nil
 }
 
-// GetConfig returns a copy of the configuration used by the runtime
-func (r *Runtime) GetConfig() *RuntimeConfig {
+// checkValid returns ErrRuntimeStopped if the runtime has already been shut
+// down via Shutdown. Unlike the coarse lock this used to be folded into, it
+// only needs to be held long enough to read r.valid - the state backing the
+// runtime and the per-container/per-pod SHM locks are what actually keep
+// concurrent operations safe, including across processes, so nothing else
+// needs to serialize on the runtime itself
+func (r *Runtime) checkValid() error {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
 	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	return nil
+}
+
+// Migrate upgrades a BoltDB-backed runtime's on-disk state to the
+// schema version compiled into this binary. It is a no-op for the
+// in-memory state, since that state is never persisted across restarts
+func (r *Runtime) Migrate() error {
+	if r.stateType != BoltDBState {
+		return nil
+	}
+
+	dbPath := filepath.Join(r.stateDir, boltDBFile)
+
+	// The runtime's own state holds the database open, and BoltDB does not
+	// allow opening it a second time, so read the version through it
+	boltState, ok := r.state.(*BoltState)
+	if !ok || !boltState.valid {
+		return errors.Wrapf(ErrDBClosed, "cannot read schema version of database %s", dbPath)
+	}
+	onDiskVersion, err := readSchemaVersion(boltState.db)
+	if err != nil {
+		return errors.Wrapf(err, "error determining schema version of database %s", dbPath)
+	}
+
+	if onDiskVersion == schemaVersion {
+		return nil
+	}
+
+	if onDiskVersion > schemaVersion {
+		return errors.Wrapf(ErrDBBadConfig, "database %s is schema version %d, newer than the %d this runtime understands", dbPath, onDiskVersion, schemaVersion)
+	}
+
+	// There is currently only one schema version, so there is nothing to
+	// upgrade from yet. Future schema bumps will add per-version
+	// migration steps here, walking the database forward one version at
+	// a time
+	return errors.Wrapf(ErrNotImplemented, "no migration path exists from schema version %d to %d", onDiskVersion, schemaVersion)
+}
+
+// Renumber walks every container and pod in the runtime's state and
+// reassigns their lock numbers, recovering from a prior run that crashed
+// and leaked lock slots
+func (r *Runtime) Renumber() error {
+	if err := r.checkValid(); err != nil {
+		return err
+	}
+
+	ctrs, err := r.state.GetAllContainers()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving containers to renumber locks")
+	}
+
+	pods, err := r.state.GetAllPods()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving pods to renumber locks")
+	}
+
+	for _, ctr := range ctrs {
+		ctrLock, err := r.lockManager.AllocateLock()
+		if err != nil {
+			return errors.Wrapf(err, "error allocating new lock for container %s", ctr.ID())
+		}
+
+		ctr.config.LockID = ctrLock.ID()
+		if err := r.state.SaveContainer(ctr); err != nil {
+			return errors.Wrapf(err, "error saving new lock ID for container %s", ctr.ID())
+		}
+	}
+
+	for _, pod := range pods {
+		podLock, err := r.lockManager.AllocateLock()
+		if err != nil {
+			return errors.Wrapf(err, "error allocating new lock for pod %s", pod.ID())
+		}
+
+		pod.config.LockID = podLock.ID()
+		if err := r.state.SavePod(pod); err != nil {
+			return errors.Wrapf(err, "error saving new lock ID for pod %s", pod.ID())
+		}
+	}
+
+	return nil
+}
+
+// GetConfig returns a copy of the configuration used by the runtime
+func (r *Runtime) GetConfig() *RuntimeConfig {
+	if err := r.checkValid(); err != nil {
 		return nil
 	}
 
@@ -128,10 +383,49 @@ func (r *Runtime) Shutdown(force bool) error {
 
 	r.valid = false
 
+	if err := r.state.Close(); err != nil {
+		return errors.Wrapf(err, "error shutting down runtime state")
+	}
+
 	_, err := r.store.Shutdown(force)
 	return err
 }
 
+// emitEvent records a lifecycle event via the runtime's configured event
+// backend. It is a no-op if the runtime was configured with
+// WithEventsLogger("none"). labels is copied into the recorded event for use
+// by events.LabelFilter, and may be nil if the entity the event pertains to
+// has no labels to offer
+func (r *Runtime) emitEvent(id, name string, eventType events.Type, status events.Status, labels map[string]string) error {
+	if r.eventer == nil {
+		return nil
+	}
+
+	return r.eventer.Write(events.Event{
+		ID:     id,
+		Name:   name,
+		Type:   eventType,
+		Status: status,
+		Time:   time.Now(),
+		Labels: labels,
+	})
+}
+
+// Events returns a channel of lifecycle events recorded for containers and
+// pods, matching every filter given. If the runtime was configured with
+// WithEventsLogger("none"), an error is returned instead
+func (r *Runtime) Events(ctx context.Context, filters []events.EventFilter) (<-chan events.Event, error) {
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
+
+	if r.eventer == nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "runtime was configured without an event logger")
+	}
+
+	return r.eventer.Read(ctx, events.ReadOptions{Filters: filters, Stream: true})
+}
+
 // Container API
 
 // A CtrCreateOption is a functional option which alters the Container created
@@ -145,17 +439,22 @@ type ContainerFilter func(*Container) bool
 
 // NewContainer creates a new container from a given OCI config
 func (r *Runtime) NewContainer(spec *spec.Spec, options ...CtrCreateOption) (*Container, error) {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	if err := r.checkValid(); err != nil {
+		return nil, err
 	}
 
 	ctr, err := newContainer(spec)
 	if err != nil {
 		return nil, err
 	}
+	ctr.runtime = r
+
+	ctrLock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error allocating lock for new container")
+	}
+	ctr.lock = ctrLock
+	ctr.config.LockID = ctrLock.ID()
 
 	for _, option := range options {
 		if err := option(ctr); err != nil {
@@ -173,12 +472,20 @@ func (r *Runtime) NewContainer(spec *spec.Spec, options ...CtrCreateOption) (*Co
 			}
 		}
 
+		if err2 := r.lockManager.FreeLock(ctrLock.ID()); err2 != nil {
+			return nil, errors.Wrapf(err, "error adding new container to state, could not free lock %d", ctrLock.ID())
+		}
+
 		// TODO: Might be worth making an effort to detect duplicate IDs
 		// We can recover from that by generating a new ID for the
 		// container
 		return nil, errors.Wrapf(err, "error adding new container to state")
 	}
 
+	if err := r.emitEvent(ctr.ID(), ctr.Name(), events.TypeContainer, events.StatusCreate, ctr.Labels()); err != nil {
+		return nil, errors.Wrapf(err, "error recording container create event")
+	}
+
 	return ctr, nil
 }
 
@@ -186,28 +493,173 @@ func (r *Runtime) NewContainer(spec *spec.Spec, options ...CtrCreateOption) (*Co
 // If force is specified, the container will be stopped first
 // Otherwise, RemoveContainer will return an error if the container is running
 func (r *Runtime) RemoveContainer(c *Container, force bool) error {
-	return ErrNotImplemented
+	if err := r.checkValid(); err != nil {
+		return err
+	}
+
+	return r.removeContainer(c, force)
+}
+
+// removeContainer performs the actual work of removing a single container:
+// tearing it down, then immediately committing that removal to state. Pod
+// removal needs the two halves kept separate - see RemovePod - so they are
+// split into teardownContainer and commitContainerRemoval below
+func (r *Runtime) removeContainer(ctr *Container, force bool) error {
+	if err := r.teardownContainer(ctr, force, nil); err != nil {
+		return err
+	}
+
+	return r.commitContainerRemoval(ctr)
+}
+
+// teardownContainer stops and deletes a container from the OCI runtime,
+// unmounts its storage, and detaches it from its pod (if any), but does not
+// touch the runtime's state. It takes the container's own lock for the
+// duration, so it is safe to call concurrently for different containers -
+// including from another process sharing the same lock manager - but must
+// not be called twice for the same container at once. Callers must follow a
+// successful call with commitContainerRemoval once every container in the
+// batch being removed has torn down successfully
+//
+// batch is the set of IDs of containers being torn down together as part of
+// the same larger removal (see RemovePod), or nil when ctr is being removed
+// on its own. A dependent in batch is guaranteed to already have been (or be
+// about to be) torn down by that same removal - state simply has not caught
+// up yet, since every container in a batch is committed to state together
+// only once the whole batch has torn down - so it is skipped here rather
+// than recursed into, which would otherwise fail trying to tear down an
+// already-torn-down container
+func (r *Runtime) teardownContainer(ctr *Container, force bool, batch map[string]bool) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	allDependents, err := r.state.ContainerInUse(ctr)
+	if err != nil {
+		return errors.Wrapf(err, "error checking if container %s is in use", ctr.ID())
+	}
+
+	dependents := make([]string, 0, len(allDependents))
+	for _, depID := range allDependents {
+		if !batch[depID] {
+			dependents = append(dependents, depID)
+		}
+	}
+
+	if len(dependents) != 0 {
+		if !force {
+			return &InUseError{ID: ctr.ID(), Dependents: dependents}
+		}
+
+		for _, depID := range dependents {
+			dep, err := r.state.GetContainer(depID)
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving dependent container %s of container %s", depID, ctr.ID())
+			}
+			if err := r.setupContainer(dep); err != nil {
+				return err
+			}
+			if err := r.removeContainer(dep, force); err != nil {
+				return errors.Wrapf(err, "error removing dependent container %s of container %s", depID, ctr.ID())
+			}
+		}
+	}
+
+	// ctr.lock is already held above, so read the state directly instead
+	// of through State(), which takes the same lock
+	state := ctr.state.State
+
+	switch state {
+	case ContainerStateRunning, ContainerStatePaused:
+		if !force {
+			return errors.Wrapf(ErrCtrStateInvalid, "container %s is running or paused, cannot remove without force", ctr.ID())
+		}
+		if err := r.ociRuntime.stopContainer(ctr); err != nil {
+			return errors.Wrapf(err, "error stopping container %s", ctr.ID())
+		}
+		if err := r.emitEvent(ctr.ID(), ctr.Name(), events.TypeContainer, events.StatusStop, ctr.Labels()); err != nil {
+			return errors.Wrapf(err, "error recording container stop event")
+		}
+	}
+
+	if state != ContainerStateConfigured {
+		if err := r.ociRuntime.deleteContainer(ctr); err != nil {
+			return errors.Wrapf(err, "error deleting container %s from OCI runtime", ctr.ID())
+		}
+	}
+
+	if ctr.config.StaticDir != "" {
+		if _, err := r.store.Unmount(ctr.ID(), force); err != nil {
+			return errors.Wrapf(err, "error unmounting container %s storage", ctr.ID())
+		}
+		if err := os.RemoveAll(ctr.config.StaticDir); err != nil {
+			return errors.Wrapf(err, "error removing container %s state directory", ctr.ID())
+		}
+	}
+
+	if ctr.pod != nil {
+		if err := ctr.pod.removeContainer(ctr); err != nil {
+			return errors.Wrapf(err, "error removing container %s from pod %s", ctr.ID(), ctr.pod.ID())
+		}
+	}
+
+	return nil
+}
+
+// commitContainerRemoval removes an already-torn-down container from state
+// and frees its lock. It must only be called after teardownContainer has
+// already succeeded for ctr
+func (r *Runtime) commitContainerRemoval(ctr *Container) error {
+	if err := r.state.RemoveContainer(ctr); err != nil {
+		return errors.Wrapf(err, "error removing container %s from state", ctr.ID())
+	}
+
+	if err := r.lockManager.FreeLock(ctr.config.LockID); err != nil {
+		return errors.Wrapf(err, "error freeing lock for container %s", ctr.ID())
+	}
+
+	if err := r.emitEvent(ctr.ID(), ctr.Name(), events.TypeContainer, events.StatusRemove, ctr.Labels()); err != nil {
+		return errors.Wrapf(err, "error recording container remove event")
+	}
+
+	return nil
+}
+
+// setupContainer attaches a runtime and lock to a container retrieved from
+// state, neither of which are persisted to disk
+func (r *Runtime) setupContainer(ctr *Container) error {
+	ctrLock, err := r.lockManager.RetrieveLock(ctr.config.LockID)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving lock %d for container %s", ctr.config.LockID, ctr.ID())
+	}
+
+	ctr.lock = ctrLock
+	ctr.runtime = r
+
+	return nil
 }
 
 // GetContainer retrieves a container by its ID
 func (r *Runtime) GetContainer(id string) (*Container, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
 
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	ctr, err := r.state.GetContainer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.setupContainer(ctr); err != nil {
+		return nil, err
 	}
 
-	return r.state.GetContainer(id)
+	return ctr, nil
 }
 
 // HasContainer checks if a container with the given ID is present
 func (r *Runtime) HasContainer(id string) (bool, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
-
-	if !r.valid {
-		return false, ErrRuntimeStopped
+	if err := r.checkValid(); err != nil {
+		return false, err
 	}
 
 	return r.state.HasContainer(id)
@@ -216,14 +668,20 @@ func (r *Runtime) HasContainer(id string) (bool, error) {
 // LookupContainer looks up a container by its name or a partial ID
 // If a partial ID is not unique, an error will be returned
 func (r *Runtime) LookupContainer(idOrName string) (*Container, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
 
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	ctr, err := r.state.LookupContainer(idOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.setupContainer(ctr); err != nil {
+		return nil, err
 	}
 
-	return r.state.LookupContainer(idOrName)
+	return ctr, nil
 }
 
 // Containers retrieves all containers from the state
@@ -231,11 +689,8 @@ func (r *Runtime) LookupContainer(idOrName string) (*Container, error) {
 // the output. Multiple filters are handled by ANDing their output, so only
 // containers matching all filters are returned
 func (r *Runtime) Containers(filters ...ContainerFilter) ([]*Container, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
-
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	if err := r.checkValid(); err != nil {
+		return nil, err
 	}
 
 	ctrs, err := r.state.GetAllContainers()
@@ -243,6 +698,12 @@ func (r *Runtime) Containers(filters ...ContainerFilter) ([]*Container, error) {
 		return nil, err
 	}
 
+	for _, ctr := range ctrs {
+		if err := r.setupContainer(ctr); err != nil {
+			return nil, err
+		}
+	}
+
 	ctrsFiltered := make([]*Container, 0, len(ctrs))
 
 	for _, ctr := range ctrs {
@@ -272,17 +733,22 @@ type PodFilter func(*Pod) bool
 
 // NewPod makes a new, empty pod
 func (r *Runtime) NewPod(options ...PodCreateOption) (*Pod, error) {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	if err := r.checkValid(); err != nil {
+		return nil, err
 	}
 
 	pod, err := newPod()
 	if err != nil {
 		return nil, errors.Wrapf(err, "error creating pod")
 	}
+	pod.runtime = r
+
+	podLock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error allocating lock for new pod")
+	}
+	pod.lock = podLock
+	pod.config.LockID = podLock.ID()
 
 	for _, option := range options {
 		if err := option(pod); err != nil {
@@ -293,39 +759,151 @@ func (r *Runtime) NewPod(options ...PodCreateOption) (*Pod, error) {
 	pod.valid = true
 
 	if err := r.state.AddPod(pod); err != nil {
+		if err2 := r.lockManager.FreeLock(podLock.ID()); err2 != nil {
+			return nil, errors.Wrapf(err, "error adding pod to state, could not free lock %d", podLock.ID())
+		}
 		return nil, errors.Wrapf(err, "error adding pod to state")
 	}
 
-	return nil, ErrNotImplemented
+	// PodConfig carries no labels of its own, unlike ContainerConfig's OCI
+	// spec annotations - see the "label" case in filters.GeneratePodFilterFuncs,
+	// which rejects it as unsupported for the same reason
+	if err := r.emitEvent(pod.ID(), pod.Name(), events.TypePod, events.StatusCreate, nil); err != nil {
+		return nil, errors.Wrapf(err, "error recording pod create event")
+	}
+
+	return pod, nil
 }
 
 // RemovePod removes a pod and all containers in it
 // If force is specified, all containers in the pod will be stopped first
 // Otherwise, RemovePod will return an error if any container in the pod is running
-// Remove acts atomically, removing all containers or no containers
+// Remove acts atomically at the state layer: every container in the pod and
+// the pod itself are committed to state in a single operation, so a
+// mid-operation failure never leaves state with only some of the pod's
+// containers removed
 func (r *Runtime) RemovePod(p *Pod, force bool) error {
-	return ErrNotImplemented
+	if err := r.checkValid(); err != nil {
+		return err
+	}
+
+	podCtrs, err := r.state.PodContainers(p)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving containers in pod %s", p.ID())
+	}
+
+	if len(podCtrs) != 0 && !force {
+		dependents := make([]string, 0, len(podCtrs))
+		for _, ctr := range podCtrs {
+			dependents = append(dependents, ctr.ID())
+		}
+		return &InUseError{ID: p.ID(), Dependents: dependents}
+	}
+
+	// Tear down the infra container last, since other containers in the
+	// pod may depend on its shared namespaces
+	removeOrder := make([]*Container, 0, len(podCtrs))
+	var infraCtr *Container
+	for _, ctr := range podCtrs {
+		if err := r.setupContainer(ctr); err != nil {
+			return err
+		}
+
+		if ctr.ID() == p.config.InfraContainerID {
+			infraCtr = ctr
+			continue
+		}
+
+		removeOrder = append(removeOrder, ctr)
+	}
+	if infraCtr != nil {
+		removeOrder = append(removeOrder, infraCtr)
+	}
+
+	// Every container in removeOrder is torn down in this same batch, so
+	// none of them should be treated as blocking another's removal just
+	// because state hasn't been updated yet - see the batch parameter of
+	// teardownContainer
+	batch := make(map[string]bool, len(removeOrder))
+	for _, ctr := range removeOrder {
+		batch[ctr.ID()] = true
+	}
+
+	// Stop, delete, and unmount every container in the pod before
+	// committing anything to state. A failure here (say, on the third of
+	// five containers) leaves every container in the pod - and the pod
+	// itself - exactly as they were in state, since nothing has been
+	// removed from state yet
+	for _, ctr := range removeOrder {
+		if err := r.teardownContainer(ctr, force, batch); err != nil {
+			return errors.Wrapf(err, "error removing container %s from pod %s", ctr.ID(), p.ID())
+		}
+	}
+
+	// Every container tore down successfully - commit the removal of
+	// the whole pod, containers and all, to state as a single atomic
+	// operation
+	if err := r.state.RemovePodContainers(p, removeOrder); err != nil {
+		return errors.Wrapf(err, "error removing pod %s from state", p.ID())
+	}
+
+	for _, ctr := range removeOrder {
+		if err := r.lockManager.FreeLock(ctr.config.LockID); err != nil {
+			return errors.Wrapf(err, "error freeing lock for container %s", ctr.ID())
+		}
+		if err := r.emitEvent(ctr.ID(), ctr.Name(), events.TypeContainer, events.StatusRemove, ctr.Labels()); err != nil {
+			return errors.Wrapf(err, "error recording container remove event")
+		}
+	}
+
+	if err := r.lockManager.FreeLock(p.config.LockID); err != nil {
+		return errors.Wrapf(err, "error freeing lock for pod %s", p.ID())
+	}
+
+	// See the comment in NewPod: pods have no label source of their own
+	if err := r.emitEvent(p.ID(), p.Name(), events.TypePod, events.StatusRemove, nil); err != nil {
+		return errors.Wrapf(err, "error recording pod remove event")
+	}
+
+	return nil
+}
+
+// setupPod attaches a runtime and lock to a pod retrieved from state,
+// neither of which are persisted to disk
+func (r *Runtime) setupPod(pod *Pod) error {
+	podLock, err := r.lockManager.RetrieveLock(pod.config.LockID)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving lock %d for pod %s", pod.config.LockID, pod.ID())
+	}
+
+	pod.lock = podLock
+	pod.runtime = r
+
+	return nil
 }
 
 // GetPod retrieves a pod by its ID
 func (r *Runtime) GetPod(id string) (*Pod, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
 
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	pod, err := r.state.GetPod(id)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.state.GetPod(id)
+	if err := r.setupPod(pod); err != nil {
+		return nil, err
+	}
+
+	return pod, nil
 }
 
 // HasPod checks to see if a pod with the given ID exists
 func (r *Runtime) HasPod(id string) (bool, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
-
-	if !r.valid {
-		return false, ErrRuntimeStopped
+	if err := r.checkValid(); err != nil {
+		return false, err
 	}
 
 	return r.state.HasPod(id)
@@ -334,14 +912,20 @@ func (r *Runtime) HasPod(id string) (bool, error) {
 // LookupPod retrieves a pod by its name or a partial ID
 // If a partial ID is not unique, an error will be returned
 func (r *Runtime) LookupPod(idOrName string) (*Pod, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
 
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	pod, err := r.state.LookupPod(idOrName)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.state.LookupPod(idOrName)
+	if err := r.setupPod(pod); err != nil {
+		return nil, err
+	}
+
+	return pod, nil
 }
 
 // Pods retrieves all pods
@@ -349,11 +933,8 @@ func (r *Runtime) LookupPod(idOrName string) (*Pod, error) {
 // output. Multiple filters are handled by ANDing their output, so only pods
 // matching all filters are returned
 func (r *Runtime) Pods(filters ...PodFilter) ([]*Pod, error) {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
-
-	if !r.valid {
-		return nil, ErrRuntimeStopped
+	if err := r.checkValid(); err != nil {
+		return nil, err
 	}
 
 	pods, err := r.state.GetAllPods()
@@ -361,6 +942,12 @@ func (r *Runtime) Pods(filters ...PodFilter) ([]*Pod, error) {
 		return nil, err
 	}
 
+	for _, pod := range pods {
+		if err := r.setupPod(pod); err != nil {
+			return nil, err
+		}
+	}
+
 	podsFiltered := make([]*Pod, 0, len(pods))
 	for _, pod := range pods {
 		include := true
@@ -375,3 +962,164 @@ func (r *Runtime) Pods(filters ...PodFilter) ([]*Pod, error) {
 
 	return podsFiltered, nil
 }
+
+// Volume API
+
+// A VolumeCreateOption is a functional option which alters the Volume
+// created by NewVolume
+type VolumeCreateOption func(*Volume) error
+
+// VolumeFilter is a function to determine whether a volume is included in
+// command output. Volumes to be outputted are tested using the function. A
+// true return will include the volume, a false return will exclude it.
+type VolumeFilter func(*Volume) bool
+
+// NewVolume creates a new named volume
+func (r *Runtime) NewVolume(name string, options ...VolumeCreateOption) (*Volume, error) {
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
+
+	vol, err := newVolume(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating volume")
+	}
+	vol.runtime = r
+
+	for _, option := range options {
+		if err := option(vol); err != nil {
+			return nil, errors.Wrapf(err, "error running volume create option")
+		}
+	}
+
+	if vol.config.Driver == "" {
+		vol.config.Driver = localDriverName
+	}
+
+	driver, ok := r.volumeDrivers[vol.config.Driver]
+	if !ok {
+		return nil, errors.Wrapf(ErrInvalidArg, "no volume driver named %s is registered", vol.config.Driver)
+	}
+
+	mountPoint, err := driver.Create(vol.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating storage for volume %s", vol.Name())
+	}
+	vol.config.MountPoint = mountPoint
+
+	vol.valid = true
+
+	if err := r.state.AddVolume(vol); err != nil {
+		if err2 := driver.Remove(vol.Name()); err2 != nil {
+			return nil, errors.Wrapf(err, "error adding volume to state, could not remove volume storage")
+		}
+		return nil, errors.Wrapf(err, "error adding volume to state")
+	}
+
+	return vol, nil
+}
+
+// RemoveVolume removes the given volume
+// If force is specified, the volume will be removed even if it is still in
+// use by one or more containers
+func (r *Runtime) RemoveVolume(v *Volume, force bool) error {
+	if err := r.checkValid(); err != nil {
+		return err
+	}
+
+	users, err := r.state.VolumeInUse(v)
+	if err != nil {
+		return errors.Wrapf(err, "error checking if volume %s is in use", v.Name())
+	}
+	if len(users) != 0 && !force {
+		return &InUseError{ID: v.Name(), Dependents: users}
+	}
+
+	driver, ok := r.volumeDrivers[v.config.Driver]
+	if !ok {
+		return errors.Wrapf(ErrInvalidArg, "no volume driver named %s is registered", v.config.Driver)
+	}
+
+	if err := r.state.RemoveVolume(v); err != nil {
+		return errors.Wrapf(err, "error removing volume %s from state", v.Name())
+	}
+
+	if err := driver.Remove(v.Name()); err != nil {
+		return errors.Wrapf(err, "error removing storage for volume %s", v.Name())
+	}
+
+	return nil
+}
+
+// GetVolume retrieves a volume by its name
+func (r *Runtime) GetVolume(name string) (*Volume, error) {
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
+
+	vol, err := r.state.GetVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	vol.runtime = r
+
+	return vol, nil
+}
+
+// HasVolume checks if a volume with the given name exists
+func (r *Runtime) HasVolume(name string) (bool, error) {
+	if err := r.checkValid(); err != nil {
+		return false, err
+	}
+
+	return r.state.HasVolume(name)
+}
+
+// LookupVolume retrieves a volume by its full name or a partial name
+// If a partial name is not unique, an error will be returned
+func (r *Runtime) LookupVolume(name string) (*Volume, error) {
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
+
+	vol, err := r.state.LookupVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	vol.runtime = r
+
+	return vol, nil
+}
+
+// Volumes retrieves all volumes
+// Filters can be provided which will determine which volumes are included in
+// the output. Multiple filters are handled by ANDing their output, so only
+// volumes matching all filters are returned
+func (r *Runtime) Volumes(filters ...VolumeFilter) ([]*Volume, error) {
+	if err := r.checkValid(); err != nil {
+		return nil, err
+	}
+
+	vols, err := r.state.GetAllVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vol := range vols {
+		vol.runtime = r
+	}
+
+	volsFiltered := make([]*Volume, 0, len(vols))
+	for _, vol := range vols {
+		include := true
+		for _, filter := range filters {
+			include = include && filter(vol)
+		}
+
+		if include {
+			volsFiltered = append(volsFiltered, vol)
+		}
+	}
+
+	return volsFiltered, nil
+}
@@ -0,0 +1,81 @@
+package libpod
+
+// State is a storage backend for libpod's current state. It tracks
+// containers and pods, their association with one another, and the names
+// and IDs that have been allocated to them. Implementations must be safe
+// for concurrent use within a process; the BoltDB-backed state can also be
+// shared by multiple processes, the in-memory state cannot.
+type State interface {
+	// Close performs any cleanup required to shut down the state
+	// properly. No further state operations should be performed after
+	// Close is called
+	Close() error
+
+	// AddContainer adds a container to the state
+	// If the container belongs to a pod, that pod must already be
+	// present in the state
+	AddContainer(ctr *Container) error
+	// RemoveContainer removes a container from the state
+	// The container will only be removed from the state, not from the
+	// pod the container belongs to (if any)
+	RemoveContainer(ctr *Container) error
+	// UpdateContainer refreshes a container's state from the database
+	UpdateContainer(ctr *Container) error
+	// SaveContainer saves a container's current state to the database
+	SaveContainer(ctr *Container) error
+	// GetContainer retrieves a container from its full ID
+	GetContainer(id string) (*Container, error)
+	// HasContainer checks if a container with the given ID is present in
+	// the state
+	HasContainer(id string) (bool, error)
+	// LookupContainer retrieves a container from a partial ID or name
+	LookupContainer(idOrName string) (*Container, error)
+	// GetAllContainers retrieves all containers in the state
+	GetAllContainers() ([]*Container, error)
+	// ContainerInUse returns the IDs of containers that depend on the
+	// given container - for example, by sharing one of its namespaces -
+	// and so would block its removal unless force is used
+	ContainerInUse(ctr *Container) ([]string, error)
+
+	// AddPod adds a pod to the state
+	AddPod(pod *Pod) error
+	// RemovePod removes a pod from the state
+	// The pod's containers must already have been removed, or this will
+	// fail
+	RemovePod(pod *Pod) error
+	// SavePod saves a pod's current state to the database
+	SavePod(pod *Pod) error
+	// GetPod retrieves a pod from its full ID
+	GetPod(id string) (*Pod, error)
+	// HasPod checks if a pod with the given ID is present in the state
+	HasPod(id string) (bool, error)
+	// LookupPod retrieves a pod from a partial ID or name
+	LookupPod(idOrName string) (*Pod, error)
+	// GetAllPods retrieves all pods in the state
+	GetAllPods() ([]*Pod, error)
+	// PodContainers retrieves all containers belonging to a given pod
+	PodContainers(pod *Pod) ([]*Container, error)
+	// RemovePodContainers removes a pod's member containers and the pod
+	// itself as a single atomic operation - either all of the given
+	// containers and the pod are removed, or none of them are
+	RemovePodContainers(pod *Pod, ctrs []*Container) error
+
+	// AddVolume adds a volume to the state
+	AddVolume(volume *Volume) error
+	// RemoveVolume removes a volume from the state
+	RemoveVolume(volume *Volume) error
+	// GetVolume retrieves a volume from its name
+	GetVolume(name string) (*Volume, error)
+	// LookupVolume retrieves a volume from its full name or a partial
+	// name
+	LookupVolume(name string) (*Volume, error)
+	// HasVolume checks if a volume with the given name is present in the
+	// state
+	HasVolume(name string) (bool, error)
+	// GetAllVolumes retrieves all volumes in the state
+	GetAllVolumes() ([]*Volume, error)
+	// VolumeInUse returns the IDs of containers that reference the given
+	// volume in their configuration, and so would block its removal
+	// unless force is used
+	VolumeInUse(volume *Volume) ([]string, error)
+}
@@ -0,0 +1,75 @@
+package libpod
+
+import (
+	"time"
+
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// Volume is a libpod named volume
+// Volumes are not owned by any single container. Containers reference them
+// by name in their configuration, and a volume cannot be removed while any
+// container still does so unless force is specified
+type Volume struct {
+	config *VolumeConfig
+
+	runtime *Runtime
+	valid   bool
+}
+
+// VolumeConfig holds the static configuration of a volume
+type VolumeConfig struct {
+	Name string
+
+	Labels map[string]string
+
+	// Driver is the name of the VolumeDriver that created and manages
+	// this volume's on-disk storage
+	Driver string
+
+	// MountPoint is the path on disk that should be bind-mounted into
+	// containers using this volume
+	MountPoint string
+
+	CreatedTime time.Time
+}
+
+// newVolume creates a new, empty volume. If name is empty, a random name is
+// generated
+func newVolume(name string) (*Volume, error) {
+	if name == "" {
+		name = stringid.GenerateNonCryptoID()
+	}
+
+	vol := new(Volume)
+	vol.config = new(VolumeConfig)
+	vol.config.Name = name
+	vol.config.CreatedTime = time.Now()
+
+	return vol, nil
+}
+
+// Name returns the volume's name
+func (v *Volume) Name() string {
+	return v.config.Name
+}
+
+// Labels returns a copy of the volume's labels
+func (v *Volume) Labels() map[string]string {
+	labels := make(map[string]string, len(v.config.Labels))
+	for k, val := range v.config.Labels {
+		labels[k] = val
+	}
+
+	return labels
+}
+
+// Driver returns the name of the VolumeDriver backing the volume
+func (v *Volume) Driver() string {
+	return v.config.Driver
+}
+
+// MountPoint returns the path on disk backing the volume
+func (v *Volume) MountPoint() string {
+	return v.config.MountPoint
+}
@@ -0,0 +1,60 @@
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// localDriverName is the name the built-in VolumeDriver is registered under
+const localDriverName = "local"
+
+// VolumeDriver creates and removes the on-disk storage backing a volume.
+// External drivers can be registered alongside the built-in local driver via
+// WithVolumeDriver
+type VolumeDriver interface {
+	// Name is the name the driver is registered and selected by
+	Name() string
+	// Create prepares on-disk storage for a newly created volume and
+	// returns the path that should be bind-mounted into containers
+	// using it
+	Create(name string) (string, error)
+	// Remove tears down the on-disk storage created for a volume by
+	// Create
+	Remove(name string) error
+}
+
+// localVolumeDriver is the default VolumeDriver. It stores each volume's
+// data as a directory under volumesDir, bind-mounted into containers
+type localVolumeDriver struct {
+	volumesDir string
+}
+
+// newLocalVolumeDriver creates a VolumeDriver that stores volumes as
+// directories under volumesDir
+func newLocalVolumeDriver(volumesDir string) *localVolumeDriver {
+	return &localVolumeDriver{volumesDir: volumesDir}
+}
+
+// Name returns "local"
+func (d *localVolumeDriver) Name() string {
+	return localDriverName
+}
+
+// Create makes the <graphroot>/volumes/<name>/_data directory backing a
+// local volume
+func (d *localVolumeDriver) Create(name string) (string, error) {
+	dataDir := filepath.Join(d.volumesDir, name, "_data")
+
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return "", errors.Wrapf(err, "error creating volume directory %s", dataDir)
+	}
+
+	return dataDir, nil
+}
+
+// Remove deletes the directory created for a volume by Create
+func (d *localVolumeDriver) Remove(name string) error {
+	return os.RemoveAll(filepath.Join(d.volumesDir, name))
+}
@@ -0,0 +1,65 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalVolumeDriverName(t *testing.T) {
+	driver := newLocalVolumeDriver("/tmp/does-not-matter")
+	if driver.Name() != localDriverName {
+		t.Errorf("Name() = %q, want %q", driver.Name(), localDriverName)
+	}
+}
+
+func TestLocalVolumeDriverCreateAndRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-driver-test-")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	driver := newLocalVolumeDriver(dir)
+
+	dataDir, err := driver.Create("testvol")
+	if err != nil {
+		t.Fatalf("Create returned an error: %v", err)
+	}
+
+	wantDataDir := filepath.Join(dir, "testvol", "_data")
+	if dataDir != wantDataDir {
+		t.Errorf("Create returned %q, want %q", dataDir, wantDataDir)
+	}
+
+	if info, err := os.Stat(dataDir); err != nil {
+		t.Fatalf("Create did not create %s: %v", dataDir, err)
+	} else if !info.IsDir() {
+		t.Fatalf("%s exists but is not a directory", dataDir)
+	}
+
+	if err := driver.Remove("testvol"); err != nil {
+		t.Fatalf("Remove returned an error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "testvol")); !os.IsNotExist(err) {
+		t.Fatalf("Remove did not delete the volume directory, stat error: %v", err)
+	}
+}
+
+func TestLocalVolumeDriverRemoveNonexistent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-driver-test-")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	driver := newLocalVolumeDriver(dir)
+
+	// os.RemoveAll is a no-op on a path that does not exist, so removing
+	// a volume that was never created should not be an error
+	if err := driver.Remove("never-created"); err != nil {
+		t.Fatalf("Remove of a nonexistent volume returned an error: %v", err)
+	}
+}